@@ -1,53 +1,113 @@
 package basiq
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
 	"net/url"
 )
 
+// AccountStatus represents the availability of the account data.
+type AccountStatus string
+
+const (
+	AccountStatusAvailable   AccountStatus = "available"
+	AccountStatusUnavailable AccountStatus = "unavailable"
+)
+
+func (s *AccountStatus) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*s = AccountStatus(v)
+	return err
+}
+
 type AccountList struct {
 	Type  string    `json:"type"`
 	Data  []Account `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Links SelfLink  `json:"links"`
 }
 
 type Account struct {
-	Type           string `json:"type"`
-	ID             string `json:"id"`
-	AccountHolder  string `json:"accountHolder"`
-	AccountNo      string `json:"accountNo"`
-	AvailableFunds string `json:"availableFunds"`
-	Balance        string `json:"balance"`
-	Class          []struct {
-		Type    string `json:"type"`
-		Product string `json:"product"`
-	} `json:"class"`
-	Connection           string `json:"connection"`
-	Currency             string `json:"currency"`
-	Institution          string `json:"institution"`
-	LastUpdated          string `json:"lastUpdated"`
-	Name                 string `json:"name"`
-	Status               string `json:"status"`
-	TransactionIntervals []struct {
-		From string `json:"from"`
-		To   string `json:"to"`
-	} `json:"transactionIntervals"`
-	Links struct {
-		Institution  string `json:"institution"`
-		Transactions string `json:"transactions"`
-		Self         string `json:"self"`
-	} `json:"links"`
+	Type                 string                `json:"type"`
+	ID                   string                `json:"id"`
+	AccountHolder        string                `json:"accountHolder"`
+	AccountNo            string                `json:"accountNo"`
+	AvailableFunds       string                `json:"availableFunds"`
+	Balance              string                `json:"balance"`
+	Class                AccountClasses        `json:"class"`
+	Connection           string                `json:"connection"`
+	Currency             string                `json:"currency"`
+	Institution          string                `json:"institution"`
+	LastUpdated          Timestamp             `json:"lastUpdated"`
+	Name                 string                `json:"name"`
+	Status               AccountStatus         `json:"status"`
+	TransactionIntervals []TransactionInterval `json:"transactionIntervals"`
+	Links                AccountLinks          `json:"links"`
+}
+
+// AccountClass describes the type of the account (e.g. transaction, savings, credit-card) and the product name.
+type AccountClass struct {
+	Type    string `json:"type"`
+	Product string `json:"product"`
+}
+
+// AccountClasses holds the classes of the account. Some endpoints return the class as a single object instead of
+// an array, both forms are accepted.
+type AccountClasses []AccountClass
+
+func (c *AccountClasses) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*c = nil
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		var class AccountClass
+		if err := json.Unmarshal(data, &class); err != nil {
+			return err
+		}
+		*c = AccountClasses{class}
+		return nil
+	default:
+		var classes []AccountClass
+		if err := json.Unmarshal(data, &classes); err != nil {
+			return err
+		}
+		*c = classes
+		return nil
+	}
+}
+
+// Primary returns the first class of the account or an empty class when there is none.
+func (c AccountClasses) Primary() AccountClass {
+	if len(c) == 0 {
+		return AccountClass{}
+	}
+	return c[0]
+}
+
+// TransactionInterval represents a period the transactions of the account are available for.
+type TransactionInterval struct {
+	From Date `json:"from"`
+	To   Date `json:"to"`
+}
+
+type AccountLinks struct {
+	Institution  string `json:"institution"`
+	Transactions string `json:"transactions"`
+	Self         string `json:"self"`
 }
 
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) Account(ctx context.Context, userID, accountID string) (Account, error) {
+	if err := a.guard(ctx, userID, ConsentEntityAccounts); err != nil {
+		return Account{}, err
+	}
+
 	account, err := a.account(ctx, userID, accountID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return account, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -57,8 +117,12 @@ func (a *API) Account(ctx context.Context, userID, accountID string) (Account, e
 }
 
 func (a *API) Accounts(ctx context.Context, userID string) ([]Account, error) {
+	if err := a.guard(ctx, userID, ConsentEntityAccounts); err != nil {
+		return nil, err
+	}
+
 	accounts, err := a.accounts(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return accounts, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -70,7 +134,7 @@ func (a *API) Accounts(ctx context.Context, userID string) ([]Account, error) {
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) account(ctx context.Context, userID, accountID string) (Account, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "accounts", accountID)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "accounts", accountID)
 	if err != nil {
 		return Account{}, err
 	}
@@ -85,7 +149,7 @@ func (a *API) account(ctx context.Context, userID, accountID string) (Account, e
 }
 
 func (a *API) accounts(ctx context.Context, userID string) ([]Account, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "accounts")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "accounts")
 	if err != nil {
 		return nil, err
 	}
@@ -9,125 +9,212 @@ import (
 	"net/url"
 )
 
+// AffordabilityParams are encoded by MarshalJSON, unset months are omitted.
 type AffordabilityParams struct {
-	Accounts  []string `json:"accounts,omitempty"`
-	FromMonth string   `json:"fromMonth,omitempty"`
-	ToMonth   string   `json:"toMonth,omitempty"`
+	Accounts  []string
+	FromMonth Month
+	ToMonth   Month
+}
+
+// Validate checks the requested month range, months can't be in the future and FromMonth can't be after ToMonth.
+func (p AffordabilityParams) Validate() error {
+	return validateMonthRange(p.FromMonth, p.ToMonth)
+}
+
+func (p AffordabilityParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newMonthRange(p.Accounts, p.FromMonth, p.ToMonth))
 }
 
 type Affordability struct {
-	Type          string `json:"type"`
-	ID            string `json:"id"`
-	CoverageDays  int    `json:"coverageDays"`
-	FromMonth     string `json:"fromMonth"`
-	ToMonth       string `json:"toMonth"`
-	GeneratedDate string `json:"generatedDate"`
-	Assets        []struct {
-		Type    string `json:"type"`
-		Account struct {
-			Product string `json:"product"`
-			Type    string `json:"type"`
-		} `json:"account"`
-		AvailableFunds  string `json:"availableFunds"`
-		Balance         string `json:"balance"`
-		Currency        string `json:"currency"`
-		Institution     string `json:"institution"`
-		Previous6Months struct {
-			MaxBalance string `json:"maxBalance"`
-			MinBalance string `json:"minBalance"`
-		} `json:"previous6Months"`
-	} `json:"assets"`
-	External []struct {
-		ChangeHistory []struct {
-			Amount string `json:"amount"`
-			Date   string `json:"date"`
-			Source string `json:"source"`
-		} `json:"changeHistory"`
-		Payments []struct {
-			AmountAvg        string `json:"amountAvg"`
-			AmountAvgMonthly string `json:"amountAvgMonthly"`
-			First            string `json:"first"`
-			Last             string `json:"last"`
-			NoOccurrences    int    `json:"noOccurrences"`
-			Total            string `json:"total"`
-		} `json:"payments"`
-		Source string `json:"source"`
-	} `json:"external"`
-	Liabilities struct {
-		Credit []struct {
-			Account struct {
-				Product string `json:"product"`
-				Type    string `json:"type"`
-			} `json:"account"`
-			AvailableFunds  string `json:"availableFunds"`
-			Balance         string `json:"balance"`
-			CreditLimit     string `json:"creditLimit"`
-			Currency        string `json:"currency"`
-			Institution     string `json:"institution"`
-			Previous6Months struct {
-				CashAdvances string `json:"cashAdvances"`
-			} `json:"previous6Months"`
-			PreviousMonth struct {
-				MaxBalance   string `json:"maxBalance"`
-				MinBalance   string `json:"minBalance"`
-				TotalCredits string `json:"totalCredits"`
-				TotalDebits  string `json:"totalDebits"`
-			} `json:"previousMonth"`
-		} `json:"credit"`
-		Loan []struct {
-			Account struct {
-				Product string `json:"product"`
-				Type    string `json:"type"`
-			} `json:"account"`
-			AvailableFunds string `json:"availableFunds"`
-			Balance        string `json:"balance"`
-			ChangeHistory  []struct {
-				Amount    string `json:"amount"`
-				Date      string `json:"date"`
-				Direction string `json:"direction"`
-				Source    string `json:"source"`
-			} `json:"changeHistory"`
-			Currency        string `json:"currency"`
-			Institution     string `json:"institution"`
-			Previous6Months struct {
-				Arrears string `json:"arrears"`
-			} `json:"previous6Months"`
-			PreviousMonth struct {
-				TotalCredits         string `json:"totalCredits"`
-				TotalDebits          string `json:"totalDebits"`
-				TotalInterestCharged string `json:"totalInterestCharged"`
-				TotalRepayments      string `json:"totalRepayments"`
-			} `json:"previousMonth"`
-		} `json:"loan"`
-	} `json:"liabilities"`
-	Summary struct {
-		Assets                      string `json:"assets"`
-		CreditLimit                 string `json:"creditLimit"`
-		Expenses                    string `json:"expenses"`
-		Liabilities                 string `json:"liabilities"`
-		LoanRepaymentMonthly        string `json:"loanRepaymentMonthly"`
-		NetPosition                 string `json:"netPosition"`
-		PotentialLiabilitiesMonthly string `json:"potentialLiabilitiesMonthly"`
-		RegularIncome               struct {
-			Previous3Months struct {
-				AvgMonthly string `json:"avgMonthly"`
-			} `json:"previous3Months"`
-		} `json:"regularIncome"`
-		Savings string `json:"savings"`
-	} `json:"summary"`
-	Links struct {
-		Accounts []string `json:"accounts"`
-		Expenses string   `json:"expenses"`
-		Income   string   `json:"income"`
-		Self     string   `json:"self"`
-	} `json:"links"`
+	Type          string                   `json:"type"`
+	ID            string                   `json:"id"`
+	CoverageDays  int                      `json:"coverageDays"`
+	FromMonth     Month                    `json:"fromMonth"`
+	ToMonth       Month                    `json:"toMonth"`
+	GeneratedDate Timestamp                `json:"generatedDate"`
+	Assets        []AffordabilityAsset     `json:"assets"`
+	External      []ExternalIncome         `json:"external"`
+	Liabilities   AffordabilityLiabilities `json:"liabilities"`
+	Summary       AffordabilityTotals      `json:"summary"`
+	Links         SnapshotLinks            `json:"links"`
+}
+
+// AccountList returns all assets and liabilities of the snapshot in the canonical account representation.
+func (a Affordability) AccountList() []Account {
+	accounts := make([]Account, 0, len(a.Assets)+len(a.Liabilities.Credit)+len(a.Liabilities.Loan))
+	for _, asset := range a.Assets {
+		accounts = append(accounts, asset.ToAccount())
+	}
+	for _, credit := range a.Liabilities.Credit {
+		accounts = append(accounts, credit.ToAccount())
+	}
+	for _, loan := range a.Liabilities.Loan {
+		accounts = append(accounts, loan.ToAccount())
+	}
+	return accounts
+}
+
+type AffordabilityAsset struct {
+	Type            string       `json:"type"`
+	Account         AccountClass `json:"account"`
+	AvailableFunds  string       `json:"availableFunds"`
+	Balance         string       `json:"balance"`
+	Currency        string       `json:"currency"`
+	Institution     string       `json:"institution"`
+	Previous6Months BalanceRange `json:"previous6Months"`
+}
+
+// ToAccount converts the asset into the canonical account representation.
+func (a AffordabilityAsset) ToAccount() Account {
+	return Account{
+		AvailableFunds: a.AvailableFunds,
+		Balance:        a.Balance,
+		Class:          AccountClasses{a.Account},
+		Currency:       a.Currency,
+		Institution:    a.Institution,
+	}
+}
+
+type BalanceRange struct {
+	MaxBalance string `json:"maxBalance"`
+	MinBalance string `json:"minBalance"`
+}
+
+// ExternalIncome represents payments received from an external source (e.g. government benefits).
+type ExternalIncome struct {
+	ChangeHistory []ChangeHistory     `json:"changeHistory"`
+	Payments      []RecurringPayments `json:"payments"`
+	Source        string              `json:"source"`
+}
+
+// ChangeHistory represents a single change of the amount in a summary, Direction and Source are set only
+// by the endpoints providing them.
+type ChangeHistory struct {
+	Amount    string `json:"amount"`
+	Date      Date   `json:"date"`
+	Direction string `json:"direction,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
+
+type RecurringPayments struct {
+	AmountAvg        string `json:"amountAvg"`
+	AmountAvgMonthly string `json:"amountAvgMonthly"`
+	First            Date   `json:"first"`
+	Last             Date   `json:"last"`
+	NoOccurrences    int    `json:"noOccurrences"`
+	Total            string `json:"total"`
+}
+
+type AffordabilityLiabilities struct {
+	Credit []CreditLiability `json:"credit"`
+	Loan   []LoanLiability   `json:"loan"`
+}
+
+type CreditLiability struct {
+	Account         AccountClass          `json:"account"`
+	AvailableFunds  string                `json:"availableFunds"`
+	Balance         string                `json:"balance"`
+	CreditLimit     string                `json:"creditLimit"`
+	Currency        string                `json:"currency"`
+	Institution     string                `json:"institution"`
+	Previous6Months CreditPrevious6Months `json:"previous6Months"`
+	PreviousMonth   CreditPreviousMonth   `json:"previousMonth"`
+}
+
+// ToAccount converts the credit liability into the canonical account representation.
+func (c CreditLiability) ToAccount() Account {
+	return Account{
+		AvailableFunds: c.AvailableFunds,
+		Balance:        c.Balance,
+		Class:          AccountClasses{c.Account},
+		Currency:       c.Currency,
+		Institution:    c.Institution,
+	}
+}
+
+type CreditPrevious6Months struct {
+	CashAdvances string `json:"cashAdvances"`
+}
+
+type CreditPreviousMonth struct {
+	MaxBalance   string `json:"maxBalance"`
+	MinBalance   string `json:"minBalance"`
+	TotalCredits string `json:"totalCredits"`
+	TotalDebits  string `json:"totalDebits"`
+}
+
+type LoanLiability struct {
+	Account         AccountClass        `json:"account"`
+	AvailableFunds  string              `json:"availableFunds"`
+	Balance         string              `json:"balance"`
+	ChangeHistory   []ChangeHistory     `json:"changeHistory"`
+	Currency        string              `json:"currency"`
+	Institution     string              `json:"institution"`
+	Previous6Months LoanPrevious6Months `json:"previous6Months"`
+	PreviousMonth   LoanPreviousMonth   `json:"previousMonth"`
+}
+
+// ToAccount converts the loan liability into the canonical account representation.
+func (l LoanLiability) ToAccount() Account {
+	return Account{
+		AvailableFunds: l.AvailableFunds,
+		Balance:        l.Balance,
+		Class:          AccountClasses{l.Account},
+		Currency:       l.Currency,
+		Institution:    l.Institution,
+	}
+}
+
+type LoanPrevious6Months struct {
+	Arrears string `json:"arrears"`
+}
+
+type LoanPreviousMonth struct {
+	TotalCredits         string `json:"totalCredits"`
+	TotalDebits          string `json:"totalDebits"`
+	TotalInterestCharged string `json:"totalInterestCharged"`
+	TotalRepayments      string `json:"totalRepayments"`
+}
+
+// AffordabilityTotals holds the overall position of the user calculated by the affordability snapshot.
+type AffordabilityTotals struct {
+	Assets                      string        `json:"assets"`
+	CreditLimit                 string        `json:"creditLimit"`
+	Expenses                    string        `json:"expenses"`
+	Liabilities                 string        `json:"liabilities"`
+	LoanRepaymentMonthly        string        `json:"loanRepaymentMonthly"`
+	NetPosition                 string        `json:"netPosition"`
+	PotentialLiabilitiesMonthly string        `json:"potentialLiabilitiesMonthly"`
+	RegularIncome               RegularTotals `json:"regularIncome"`
+	Savings                     string        `json:"savings"`
+}
+
+type RegularTotals struct {
+	Previous3Months MonthlyAverage `json:"previous3Months"`
+}
+
+type MonthlyAverage struct {
+	AvgMonthly string `json:"avgMonthly"`
+}
+
+// SnapshotLinks holds the links of the affordability, income and expense snapshots. Not all snapshots provide all
+// links.
+type SnapshotLinks struct {
+	Accounts []string `json:"accounts,omitempty"`
+	Expenses string   `json:"expenses,omitempty"`
+	Income   string   `json:"income,omitempty"`
+	Self     string   `json:"self"`
 }
 
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) Affordability(ctx context.Context, userID, snapshotID string) (Affordability, error) {
+	if err := a.guard(ctx, userID, ConsentEntityAccounts, ConsentEntityTransactions); err != nil {
+		return Affordability{}, err
+	}
+
 	affordability, err := a.affordability(ctx, userID, snapshotID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return affordability, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -137,8 +224,16 @@ func (a *API) Affordability(ctx context.Context, userID, snapshotID string) (Aff
 }
 
 func (a *API) CreateAffordability(ctx context.Context, userID string, params AffordabilityParams) (Affordability, error) {
+	if err := params.Validate(); err != nil {
+		return Affordability{}, err
+	}
+
+	if err := a.guard(ctx, userID, ConsentEntityAccounts, ConsentEntityTransactions); err != nil {
+		return Affordability{}, err
+	}
+
 	affordability, err := a.createAffordability(ctx, userID, params)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return affordability, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -150,7 +245,7 @@ func (a *API) CreateAffordability(ctx context.Context, userID string, params Aff
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) affordability(ctx context.Context, userID, snapshotID string) (Affordability, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "affordability", snapshotID)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "affordability", snapshotID)
 	if err != nil {
 		return Affordability{}, err
 	}
@@ -165,7 +260,7 @@ func (a *API) affordability(ctx context.Context, userID, snapshotID string) (Aff
 }
 
 func (a *API) createAffordability(ctx context.Context, userID string, params AffordabilityParams) (Affordability, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "affordability")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "affordability")
 	if err != nil {
 		return Affordability{}, err
 	}
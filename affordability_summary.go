@@ -10,31 +10,29 @@ import (
 type AffordabilitySummaryList struct {
 	Type  string                 `json:"type"`
 	Data  []AffordabilitySummary `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Links SelfLink               `json:"links"`
 }
 
 type AffordabilitySummary struct {
-	Type          string `json:"type"`
-	ID            string `json:"id"`
-	CoverageDays  int    `json:"coverageDays"`
-	FromMonth     string `json:"fromMonth"`
-	ToMonth       string `json:"toMonth"`
-	GeneratedDate string `json:"generatedDate"`
-	Institutions  string `json:"institutions"`
-	Links         struct {
-		Expenses string `json:"expenses"`
-		Income   string `json:"income"`
-		Self     string `json:"self"`
-	} `json:"links"`
+	Type          string        `json:"type"`
+	ID            string        `json:"id"`
+	CoverageDays  int           `json:"coverageDays"`
+	FromMonth     Month         `json:"fromMonth"`
+	ToMonth       Month         `json:"toMonth"`
+	GeneratedDate Timestamp     `json:"generatedDate"`
+	Institutions  string        `json:"institutions"`
+	Links         SnapshotLinks `json:"links"`
 }
 
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) AffordabilitySummaries(ctx context.Context, userID string) ([]AffordabilitySummary, error) {
+	if err := a.guard(ctx, userID, ConsentEntityAccounts, ConsentEntityTransactions); err != nil {
+		return nil, err
+	}
+
 	affordabilitySummaries, err := a.affordabilitySummaries(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return affordabilitySummaries, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -46,7 +44,7 @@ func (a *API) AffordabilitySummaries(ctx context.Context, userID string) ([]Affo
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) affordabilitySummaries(ctx context.Context, userID string) ([]AffordabilitySummary, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "affordability")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "affordability")
 	if err != nil {
 		return nil, err
 	}
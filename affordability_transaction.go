@@ -2,8 +2,6 @@ package basiq
 
 import (
 	"context"
-	"encoding/json"
-	"net/http"
 	"net/url"
 )
 
@@ -12,36 +10,34 @@ type AffordabilityTransactionList struct {
 	Count int                        `json:"count"`
 	Size  int                        `json:"size"`
 	Data  []AffordabilityTransaction `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-		Next string `json:"next"`
-	} `json:"links"`
+	Links PageLinks                  `json:"links"`
 }
 
 type AffordabilityTransaction struct {
-	Type            string `json:"type"`
-	ID              string `json:"id"`
-	Account         string `json:"account"`
-	Amount          string `json:"amount"`
-	Balance         string `json:"balance"`
-	Class           string `json:"class"`
-	Description     string `json:"description"`
-	Direction       string `json:"direction"`
-	Institution     string `json:"institution"`
-	PostDate        string `json:"postDate"`
-	Status          string `json:"status"`
-	TransactionDate string `json:"transactionDate"`
-	Links           struct {
-		Account     string `json:"account"`
-		Institution string `json:"institution"`
-	} `json:"links"`
+	Type            string               `json:"type"`
+	ID              string               `json:"id"`
+	Account         string               `json:"account"`
+	Amount          string               `json:"amount"`
+	Balance         string               `json:"balance"`
+	Class           TransactionClass     `json:"class"`
+	Description     string               `json:"description"`
+	Direction       TransactionDirection `json:"direction"`
+	Institution     string               `json:"institution"`
+	PostDate        Date                 `json:"postDate"`
+	Status          TransactionStatus    `json:"status"`
+	TransactionDate Date                 `json:"transactionDate"`
+	Links           TransactionLinks     `json:"links"`
 }
 
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) AffordabilityTransactions(ctx context.Context, userID, snapshotID string) ([]AffordabilityTransaction, error) {
+	if err := a.guard(ctx, userID, ConsentEntityTransactions); err != nil {
+		return nil, err
+	}
+
 	affordabilityTransactions, err := a.affordabilityTransactions(ctx, userID, snapshotID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return affordabilityTransactions, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -53,25 +49,10 @@ func (a *API) AffordabilityTransactions(ctx context.Context, userID, snapshotID
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) affordabilityTransactions(ctx context.Context, userID, snapshotID string) ([]AffordabilityTransaction, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "affordability", snapshotID, "transactions")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "affordability", snapshotID, "transactions")
 	if err != nil {
 		return nil, err
 	}
 
-	var transactions []AffordabilityTransaction
-	for callURL != "" {
-		data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		var list AffordabilityTransactionList
-		if err = json.Unmarshal(data, &list); err != nil {
-			return nil, err
-		}
-		if len(list.Data) > 0 {
-			transactions = append(transactions, list.Data...)
-		}
-		callURL = list.Links.Next
-	}
-	return transactions, nil
+	return listPages[AffordabilityTransaction](ctx, a, callURL)
 }
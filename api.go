@@ -6,12 +6,13 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 )
 
 const (
-	baseURL = "https://au-api.basiq.io"
+	defaultBaseURL = "https://au-api.basiq.io"
 )
 
 var defaultAuthPauseSec float64 = 5
@@ -28,6 +29,15 @@ type Config struct {
 	APIKey string
 	Scope  AuthScope
 	UserID string
+	// ConsentGuard enables the consent guard mode, user data endpoints are called only when the active consent
+	// of the user covers the data.
+	ConsentGuard bool
+	// ConsentCacheTTL is the time the consent guard keeps the loaded consent, defaults to 5 minutes.
+	ConsentCacheTTL time.Duration
+	// BaseURL overrides the Basiq API URL, e.g. to point the client to a fake server in tests.
+	BaseURL string
+	// HTTPClient overrides the client used for all calls, http.DefaultClient is used when it's not set.
+	HTTPClient *http.Client
 }
 
 // Validate checks all necessary input parameters and returns error when some of them are not set.
@@ -39,6 +49,10 @@ func (c Config) Validate() error {
 		return errors.New("basic scope is required")
 	case c.Scope == ClientScope && c.UserID == "":
 		return errors.New("basiq userID is required when CLIENT_ACCESS scope is used")
+	case c.ConsentCacheTTL < 0:
+		return errors.New("basiq consent cache TTL can't be negative")
+	case c.BaseURL != "" && !strings.HasPrefix(c.BaseURL, "http://") && !strings.HasPrefix(c.BaseURL, "https://"):
+		return errors.New("basiq base URL has to be an absolute HTTP URL")
 	default:
 		return nil
 	}
@@ -48,12 +62,18 @@ func (c Config) Validate() error {
 // the NewAPI method where all setup and validation of input happens.
 // API is thread safe struct.
 type API struct {
+	baseURL      string
+	client       *http.Client
 	apiKey       string
 	scope        AuthScope
 	userID       string
 	authorizedAt time.Time
 	headers      http.Header
 	m            sync.Mutex
+	consentGuard bool
+	consentTTL   time.Duration
+	consents     map[string]cachedConsent
+	consentsM    sync.Mutex
 }
 
 // NewAPI instantiates the Client struct and checks all input parameters.
@@ -62,25 +82,62 @@ func NewAPI(config Config) (*API, error) {
 		return nil, err
 	}
 
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	consentTTL := config.ConsentCacheTTL
+	if consentTTL == 0 {
+		consentTTL = defaultConsentCacheTTL
+	}
+
 	return &API{
-		apiKey:  config.APIKey,
-		scope:   config.Scope,
-		userID:  config.UserID,
-		m:       sync.Mutex{},
-		headers: defaultHeaders.Clone(),
+		baseURL:      baseURL,
+		client:       client,
+		apiKey:       config.APIKey,
+		scope:        config.Scope,
+		userID:       config.UserID,
+		m:            sync.Mutex{},
+		headers:      defaultHeaders.Clone(),
+		consentGuard: config.ConsentGuard,
+		consentTTL:   consentTTL,
+		consents:     map[string]cachedConsent{},
 	}, nil
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) makeCall(ctx context.Context, HTTPMethod, callURL string, payload io.Reader) ([]byte, error) {
+	return a.makeCallWithContentType(ctx, HTTPMethod, callURL, "", payload)
+}
+
+// makeCallWithContentType makes the call with a non JSON payload (e.g. multipart/form-data) of the content type.
+func (a *API) makeCallWithContentType(ctx context.Context, HTTPMethod, callURL, contentType string, payload io.Reader) ([]byte, error) {
+	// every request gets its own copy of the headers, Authenticate replaces the token while other calls are sent
+	a.m.Lock()
+	header := a.headers.Clone()
+	a.m.Unlock()
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return a.send(ctx, HTTPMethod, callURL, header, payload)
+}
+
+// send makes the call with the header, it's owned by the request and mustn't be shared.
+func (a *API) send(ctx context.Context, HTTPMethod, callURL string, header http.Header, payload io.Reader) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, HTTPMethod, callURL, payload)
 	if err != nil {
 		return nil, err
 	}
-	req.Header = a.headers
+	req.Header = header
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := a.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
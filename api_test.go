@@ -0,0 +1,105 @@
+package basiq_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lukasaron/basiq-go"
+	"github.com/lukasaron/basiq-go/basiqtest"
+)
+
+func TestSuccessfulCallsAreSentOnce(t *testing.T) {
+	api, _, counter := newTestAPI(t)
+	ctx := context.Background()
+	if err := api.Authenticate(ctx); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+
+	user, err := api.CreateUser(ctx, basiq.UserParams{Email: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if _, err = api.User(ctx, user.ID); err != nil {
+		t.Fatalf("User: %v", err)
+	}
+	if err = api.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	for _, call := range []struct{ method, path string }{
+		{http.MethodPost, "/users"},
+		{http.MethodGet, "/users/" + user.ID},
+		{http.MethodDelete, "/users/" + user.ID},
+	} {
+		if n := counter.count(call.method, call.path); n != 1 {
+			t.Errorf("%s %s sent %d times, want 1", call.method, call.path, n)
+		}
+	}
+}
+
+func TestUnauthorizedCallIsRetriedOnce(t *testing.T) {
+	api, srv, counter := newTestAPI(t)
+	ctx := context.Background()
+	if err := api.Authenticate(ctx); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+
+	user := srv.AddUser(basiq.User{Email: "jane@example.com"})
+	srv.InjectError(http.MethodGet, "/users/"+user.ID, http.StatusUnauthorized, 1)
+	if _, err := api.User(ctx, user.ID); err != nil {
+		t.Fatalf("User: %v", err)
+	}
+
+	if n := counter.count(http.MethodGet, "/users/"+user.ID); n != 2 {
+		t.Errorf("GET /users/%s sent %d times, want 2", user.ID, n)
+	}
+}
+
+func TestConcurrentCallsDuringAuthentication(t *testing.T) {
+	srv := basiqtest.NewServer()
+	t.Cleanup(srv.Close)
+	user := srv.AddUser(basiq.User{Email: "jane@example.com"})
+
+	var m sync.Mutex
+	var leaked []string
+	transport := srv.Client().Transport
+	config := srv.Config()
+	config.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/token" && strings.HasPrefix(req.Header.Get("Authorization"), "Basic ") {
+			m.Lock()
+			leaked = append(leaked, req.Method+" "+req.URL.Path)
+			m.Unlock()
+		}
+		return transport.RoundTrip(req)
+	})}
+	api, err := basiq.NewAPI(config)
+	if err != nil {
+		t.Fatalf("NewAPI: %v", err)
+	}
+
+	// the client isn't authenticated yet, so the calls authenticate while the others are being sent
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := api.User(context.Background(), user.ID)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("User: %v", err)
+		}
+	}
+	if len(leaked) > 0 {
+		t.Errorf("API key sent with %v", leaked)
+	}
+}
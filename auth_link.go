@@ -13,22 +13,24 @@ type AuthLinkParams struct {
 }
 
 type AuthLink struct {
-	Type      string `json:"type"`
-	ID        string `json:"id"`
-	Mobile    int64  `json:"mobile"`
-	UserID    string `json:"userId"`
-	ExpiresAt string `json:"expiresAt"`
-	Links     struct {
-		Public string `json:"public"`
-		Self   string `json:"self"`
-	} `json:"links"`
+	Type      string        `json:"type"`
+	ID        string        `json:"id"`
+	Mobile    int64         `json:"mobile"`
+	UserID    string        `json:"userId"`
+	ExpiresAt Timestamp     `json:"expiresAt"`
+	Links     AuthLinkLinks `json:"links"`
+}
+
+type AuthLinkLinks struct {
+	Public string `json:"public"`
+	Self   string `json:"self"`
 }
 
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) AuthLink(ctx context.Context, userID string) (AuthLink, error) {
 	authLink, err := a.authLink(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return authLink, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -39,7 +41,7 @@ func (a *API) AuthLink(ctx context.Context, userID string) (AuthLink, error) {
 
 func (a *API) CreateAuthLink(ctx context.Context, userID string, params AuthLinkParams) (AuthLink, error) {
 	authLink, err := a.createAuthLink(ctx, userID, params)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return authLink, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -50,7 +52,7 @@ func (a *API) CreateAuthLink(ctx context.Context, userID string, params AuthLink
 
 func (a *API) DeleteAuthLink(ctx context.Context, userID string) error {
 	err := a.deleteAuthLink(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -62,7 +64,7 @@ func (a *API) DeleteAuthLink(ctx context.Context, userID string) error {
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) authLink(ctx context.Context, userID string) (AuthLink, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "auth_link")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "auth_link")
 	if err != nil {
 		return AuthLink{}, err
 	}
@@ -77,7 +79,7 @@ func (a *API) authLink(ctx context.Context, userID string) (AuthLink, error) {
 }
 
 func (a *API) createAuthLink(ctx context.Context, userID string, params AuthLinkParams) (AuthLink, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "auth_link")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "auth_link")
 	if err != nil {
 		return AuthLink{}, err
 	}
@@ -97,7 +99,7 @@ func (a *API) createAuthLink(ctx context.Context, userID string, params AuthLink
 }
 
 func (a *API) deleteAuthLink(ctx context.Context, userID string) error {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "auth_link")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "auth_link")
 	if err != nil {
 		return err
 	}
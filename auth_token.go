@@ -50,12 +50,14 @@ func (a *API) Authenticate(ctx context.Context) error {
 // ---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) authToken(ctx context.Context, apiKey string, scope AuthScope, userID string) (AuthToken, error) {
-	callURL, err := url.JoinPath(baseURL, "token")
+	callURL, err := url.JoinPath(a.baseURL, "token")
 	if err != nil {
 		return AuthToken{}, err
 	}
 
-	a.headers.Set("Authorization", fmt.Sprintf("Basic %s", apiKey))
+	// the caller holds the lock, the API key is sent with this call only
+	header := a.headers.Clone()
+	header.Set("Authorization", fmt.Sprintf("Basic %s", apiKey))
 
 	urlValues := url.Values{
 		"scope": {string(scope)},
@@ -64,7 +66,7 @@ func (a *API) authToken(ctx context.Context, apiKey string, scope AuthScope, use
 		urlValues.Set("userId", userID)
 	}
 
-	data, err := a.makeCall(ctx, http.MethodPost, callURL, strings.NewReader(urlValues.Encode()))
+	data, err := a.send(ctx, http.MethodPost, callURL, header, strings.NewReader(urlValues.Encode()))
 	if err != nil {
 		return AuthToken{}, err
 	}
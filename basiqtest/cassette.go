@@ -0,0 +1,408 @@
+package basiqtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the scrubbed values in the cassettes.
+const Redacted = "REDACTED"
+
+// ErrCassetteMiss is returned by the Replayer for requests without a recorded interaction.
+var ErrCassetteMiss = errors.New("basiqtest cassette has no interaction for the request")
+
+// defaultScrubFields are the JSON fields holding credentials and personal data of the users.
+var defaultScrubFields = []string{
+	"access_token", "secret", "password", "securityCode", "secondaryLoginId", "loginId",
+	"email", "mobile", "firstName", "lastName", "middleName", "fullName", "name", "accountHolder",
+	"emailAddresses", "phoneNumbers", "physicalAddresses", "addressLine1", "addressLine2", "addressLine3",
+	"dob", "accountNo", "accountNumber", "bankBranchCode",
+	"payerAccountNumber", "payerBankBranchCode", "payeeAccountNumber", "payeeBankBranchCode",
+}
+
+// filterExpression matches the single expressions of the filter query parameter, e.g. email.eq('jane@example.com').
+var filterExpression = regexp.MustCompile(`([\w.]+)\.(\w+)\('([^']*)'\)`)
+
+// keptHeaders are the only headers stored in the cassettes, all others (e.g. Authorization) are dropped.
+var keptHeaders = []string{"Accept", "Content-Type", "basiq-version"}
+
+// Cassette holds the recorded interactions in the order they happened.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a single recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the scrubbed request, URI holds the path with the scrubbed query.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URI    string      `json:"uri"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the scrubbed response.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// LoadCassette reads the cassette from the file.
+func LoadCassette(path string) (Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Cassette{}, err
+	}
+
+	var cassette Cassette
+	return cassette, json.Unmarshal(data, &cassette)
+}
+
+// Save writes the cassette into the file.
+func (c Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Recorder is a http.RoundTripper passing the requests to the underlying transport and recording the scrubbed
+// interactions. Use it as the transport of Config.HTTPClient and call Save once the traffic is captured.
+// Recorder is thread safe struct.
+type Recorder struct {
+	// ScrubFields extends the JSON fields scrubbed from the request and response bodies.
+	ScrubFields []string
+
+	transport http.RoundTripper
+	cassette  Cassette
+	m         sync.Mutex
+}
+
+// NewRecorder instantiates the recorder, http.DefaultTransport is used when the transport is nil.
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Recorder{transport: transport}
+}
+
+// Client returns the HTTP client recording all calls.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Cassette returns the interactions recorded so far.
+func (r *Recorder) Cassette() Cassette {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Save writes the interactions recorded so far into the file.
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// Replayer is a http.RoundTripper serving the recorded responses without any network access. Requests are matched
+// by the method, the path with the scrubbed query and the body, every interaction is served once in the recorded
+// order. Requests without a matching interaction fail with ErrCassetteMiss.
+// Replayer is thread safe struct.
+type Replayer struct {
+	// ScrubFields has to match the fields used by the Recorder, so the request bodies are compared scrubbed.
+	ScrubFields []string
+
+	cassette Cassette
+	used     []bool
+	m        sync.Mutex
+}
+
+// NewReplayer loads the cassette from the file.
+func NewReplayer(path string) (*Replayer, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{cassette: cassette, used: make([]bool, len(cassette.Interactions))}, nil
+}
+
+// Client returns the HTTP client replaying all calls.
+func (r *Replayer) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Unused returns the interactions which haven't been served, it's useful to check the test made all recorded calls.
+func (r *Replayer) Unused() []Interaction {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := readBody(&res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := scrubFields(r.ScrubFields)
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URI:    scrubURI(req.URL.RequestURI(), fields),
+			Header: filterHeader(req.Header),
+			Body:   scrubRequestBody(req, reqBody, fields),
+		},
+		Response: RecordedResponse{
+			Status: res.StatusCode,
+			Header: filterHeader(res.Header),
+			Body:   scrubBody(resBody, fields),
+		},
+	}
+
+	r.m.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.m.Unlock()
+
+	return res, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	fields := scrubFields(r.ScrubFields)
+	body = scrubRequestBody(req, body, fields)
+	uri := scrubURI(req.URL.RequestURI(), fields)
+
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		recorded := interaction.Request
+		if r.used[i] || recorded.Method != req.Method || recorded.URI != uri || recorded.Body != body {
+			continue
+		}
+		r.used[i] = true
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s %s", ErrCassetteMiss, req.Method, uri, body)
+}
+
+// readBody reads the body and replaces it with a copy, so it can be read again.
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return "", err
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
+
+func filterHeader(header http.Header) http.Header {
+	filtered := http.Header{}
+	for _, key := range keptHeaders {
+		if values := header.Values(key); len(values) > 0 {
+			filtered[http.CanonicalHeaderKey(key)] = values
+		}
+	}
+	return filtered
+}
+
+func scrubFields(extra []string) map[string]bool {
+	fields := map[string]bool{}
+	for _, field := range append(defaultScrubFields, extra...) {
+		fields[strings.ToLower(field)] = true
+	}
+	return fields
+}
+
+// scrubURI redacts the query parameters of the scrubbed fields and the values of the filter expressions on them,
+// e.g. the email of FindUsers. The URI is returned as it is when there is nothing to redact.
+func scrubURI(uri string, fields map[string]bool) string {
+	path, rawQuery, ok := strings.Cut(uri, "?")
+	if !ok {
+		return uri
+	}
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return uri
+	}
+
+	scrubbed := false
+	for key, values := range query {
+		for i, value := range values {
+			next := value
+			switch {
+			case fields[strings.ToLower(key)] && value != "":
+				next = Redacted
+			case key == "filter":
+				next = scrubFilter(value, fields)
+			}
+			if next != value {
+				values[i], scrubbed = next, true
+			}
+		}
+	}
+	if !scrubbed {
+		return uri
+	}
+	return path + "?" + query.Encode()
+}
+
+// scrubFilter redacts the values of the filter expressions whose field is scrubbed, the field is matched by its
+// last segment, so account.email is scrubbed the same way as email.
+func scrubFilter(filter string, fields map[string]bool) string {
+	return filterExpression.ReplaceAllStringFunc(filter, func(expression string) string {
+		match := filterExpression.FindStringSubmatch(expression)
+		field := match[1][strings.LastIndex(match[1], ".")+1:]
+		if !fields[strings.ToLower(field)] || match[3] == "" {
+			return expression
+		}
+		return fmt.Sprintf("%s.%s('%s')", match[1], match[2], Redacted)
+	})
+}
+
+// scrubRequestBody scrubs the request body, multipart bodies are replaced by their fields and digests of their files,
+// so the random boundary doesn't break the matching and the files (e.g. bank statements) are not stored.
+func scrubRequestBody(req *http.Request, body string, fields map[string]bool) string {
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return scrubBody(body, fields)
+	}
+
+	var parts []string
+	reader := multipart.NewReader(strings.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return body
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return body
+		}
+
+		if part.FileName() != "" {
+			parts = append(parts, fmt.Sprintf("%s=%s sha256:%x", part.FormName(), part.FileName(), sha256.Sum256(data)))
+			continue
+		}
+		value := string(data)
+		if fields[strings.ToLower(part.FormName())] {
+			value = Redacted
+		}
+		parts = append(parts, part.FormName()+"="+value)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// scrubBody redacts the fields of the JSON body, the body is returned in the canonical form with sorted keys,
+// so the bodies can be compared. Non JSON bodies are returned as they are.
+func scrubBody(body string, fields map[string]bool) string {
+	if body == "" {
+		return body
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return body
+	}
+
+	data, err := json.Marshal(scrubValue(v, fields, false))
+	if err != nil {
+		return body
+	}
+	return string(data)
+}
+
+// scrubValue redacts all strings of the scrubbed fields including the ones nested in their arrays and objects.
+func scrubValue(v interface{}, fields map[string]bool, scrub bool) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, nested := range value {
+			value[key] = scrubValue(nested, fields, scrub || fields[strings.ToLower(key)])
+		}
+		return value
+	case []interface{}:
+		for i, nested := range value {
+			value[i] = scrubValue(nested, fields, scrub)
+		}
+		return value
+	case string:
+		if scrub && value != "" {
+			return Redacted
+		}
+		if strings.HasPrefix(value, "http") {
+			// links of the paginated lists repeat the query of the request
+			return scrubURI(value, fields)
+		}
+		return value
+	default:
+		return value
+	}
+}
@@ -0,0 +1,69 @@
+package basiqtest_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lukasaron/basiq-go"
+	"github.com/lukasaron/basiq-go/basiqtest"
+)
+
+func TestRecorderScrubsQueryAndReplays(t *testing.T) {
+	srv := basiqtest.NewServer()
+	t.Cleanup(srv.Close)
+	srv.PageSize = 1
+	srv.AddUser(basiq.User{Email: "jane@example.com", Mobile: "+61400000000"})
+	srv.AddUser(basiq.User{Email: "jane@example.com", Mobile: "+61400000000"})
+
+	recorder := basiqtest.NewRecorder(srv.Client().Transport)
+	config := srv.Config()
+	config.HTTPClient = recorder.Client()
+	api, err := basiq.NewAPI(config)
+	if err != nil {
+		t.Fatalf("NewAPI: %v", err)
+	}
+
+	params := basiq.UserParams{Email: "jane@example.com", Mobile: "+61400000000"}
+	recorded, err := api.FindUsers(context.Background(), params)
+	if err != nil {
+		t.Fatalf("FindUsers: %v", err)
+	}
+	if len(recorded) != 2 {
+		t.Fatalf("FindUsers returned %d users, want 2", len(recorded))
+	}
+
+	for _, interaction := range recorder.Cassette().Interactions {
+		for _, s := range []string{interaction.Request.URI, interaction.Response.Body} {
+			if strings.Contains(s, "jane") || strings.Contains(s, "61400000000") {
+				t.Errorf("cassette leaks personal data: %s", s)
+			}
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err = recorder.Save(path); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	replayer, err := basiqtest.NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer: %v", err)
+	}
+	config.HTTPClient = replayer.Client()
+	api, err = basiq.NewAPI(config)
+	if err != nil {
+		t.Fatalf("NewAPI: %v", err)
+	}
+
+	replayed, err := api.FindUsers(context.Background(), params)
+	if err != nil {
+		t.Fatalf("FindUsers replayed: %v", err)
+	}
+	if len(replayed) != len(recorded) {
+		t.Errorf("replayed %d users, recorded %d", len(replayed), len(recorded))
+	}
+	if unused := replayer.Unused(); len(unused) > 0 {
+		t.Errorf("%d interactions not replayed", len(unused))
+	}
+}
@@ -0,0 +1,1081 @@
+package basiqtest
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+var filterPattern = regexp.MustCompile(`([\w.]+)\.(eq|gteq)\('([^']*)'\)`)
+
+// route dispatches the request by its path segments, the caller holds the lock.
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	path := segments(r.URL.Path)
+	switch {
+	case len(path) == 1 && path[0] == "token" && r.Method == http.MethodPost:
+		s.token(w, r)
+	case len(path) >= 1 && path[0] == "users":
+		s.routeUsers(w, r, path[1:])
+	case len(path) == 2 && path[0] == "jobs" && r.Method == http.MethodGet:
+		s.job(w, path[1])
+	case len(path) == 3 && path[0] == "jobs" && path[2] == "mfa" && r.Method == http.MethodPost:
+		s.jobMFA(w, r, path[1])
+	case len(path) == 1 && path[0] == "institutions" && r.Method == http.MethodGet:
+		s.institutions(w, r)
+	case len(path) == 2 && path[0] == "institutions" && r.Method == http.MethodGet:
+		s.institution(w, path[1])
+	case len(path) == 1 && path[0] == "enrich" && r.Method == http.MethodGet:
+		s.enrich(w, r)
+	case len(path) >= 2 && path[0] == "payments":
+		s.routePayments(w, r, path[1:])
+	case len(path) == 1 && path[0] == "events" && r.Method == http.MethodGet:
+		s.events(w, r)
+	case len(path) == 2 && path[0] == "events" && r.Method == http.MethodGet:
+		s.event(w, path[1])
+	case len(path) >= 1 && path[0] == "reports":
+		s.reports(w, r, path[1:])
+	default:
+		writeError(w, http.StatusNotFound, "resource-not-found", "Resource not found")
+	}
+}
+
+func (s *Server) routeUsers(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			s.users(w, r)
+		case http.MethodPost:
+			s.createUser(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+		}
+		return
+	}
+
+	userID := path[0]
+	if !s.userExists(userID) {
+		writeError(w, http.StatusNotFound, "resource-not-found", "User not found")
+		return
+	}
+
+	resource := path[1:]
+	switch {
+	case len(resource) == 0:
+		s.user(w, r, userID)
+	case resource[0] == "connections":
+		s.connections(w, r, userID, resource[1:])
+	case resource[0] == "accounts" && r.Method == http.MethodGet:
+		s.accounts(w, userID, resource[1:])
+	case resource[0] == "transactions" && r.Method == http.MethodGet:
+		s.transactions(w, r, userID, resource[1:])
+	case resource[0] == "statements" && len(resource) == 1 && r.Method == http.MethodPost:
+		s.uploadStatement(w, r, userID)
+	case resource[0] == "jobs" && len(resource) == 1 && r.Method == http.MethodGet:
+		s.userJobs(w, userID)
+	case resource[0] == "consents":
+		s.consents(w, r, userID, resource[1:])
+	case resource[0] == "affordability":
+		s.affordability(w, r, userID, resource[1:])
+	case resource[0] == "income":
+		s.income(w, r, userID, resource[1:])
+	case resource[0] == "expenses":
+		s.expenses(w, r, userID, resource[1:])
+	default:
+		writeError(w, http.StatusNotFound, "resource-not-found", "Resource not found")
+	}
+}
+
+func (s *Server) routePayments(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case path[0] == "payrequests":
+		s.payRequests(w, r, path[1:])
+	case path[0] == "payouts":
+		s.payouts(w, r, path[1:])
+	case path[0] == "float-accounts" && r.Method == http.MethodGet:
+		s.floatAccounts(w, r, path[1:])
+	default:
+		writeError(w, http.StatusNotFound, "resource-not-found", "Resource not found")
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Basic "+APIKey {
+		writeError(w, http.StatusUnauthorized, "unauthorized-access", "Invalid API key")
+		return
+	}
+	// the client doesn't send the form content type, so the body is parsed directly
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parameter-not-valid", err.Error())
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parameter-not-valid", err.Error())
+		return
+	}
+
+	scope := basiq.AuthScope(form.Get("scope"))
+	if scope != basiq.ServerScope && scope != basiq.ClientScope {
+		writeError(w, http.StatusBadRequest, "parameter-not-valid", "Scope is not valid")
+		return
+	}
+
+	token := s.nextID("token")
+	s.tokens[token] = true
+	writeJSON(w, http.StatusOK, basiq.AuthToken{AccessToken: token, ExpiresIn: 3600, TokenType: "Bearer"})
+}
+
+// users lists the users, the "email" and "mobile" filters are supported.
+func (s *Server) users(w http.ResponseWriter, r *http.Request) {
+	filters := parseFilter(r.URL.Query().Get("filter"))
+
+	var users []basiq.User
+	for _, user := range s.state.users {
+		if matchFilter(filters, "email", user.Email) && matchFilter(filters, "mobile", user.Mobile) {
+			users = append(users, user)
+		}
+	}
+
+	page, links := s.paginate(r, len(users))
+	writeJSON(w, http.StatusOK, basiq.UserList{
+		Type:  "list",
+		Count: len(users),
+		Size:  page.end - page.start,
+		Data:  nonNil(users[page.start:page.end]),
+		Links: links,
+	})
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var params basiq.UserParams
+	if !decodeBody(w, r, &params) {
+		return
+	}
+	if params.Email == "" && params.Mobile == "" {
+		writeError(w, http.StatusBadRequest, "parameter-not-supplied", "Email or mobile is required")
+		return
+	}
+
+	user := s.addUser(basiq.User{
+		Email:     params.Email,
+		Mobile:    params.Mobile,
+		FirstName: params.FirstName,
+		LastName:  params.LastName,
+		Name:      strings.TrimSpace(params.FirstName + " " + params.LastName),
+	})
+	s.addEvent(basiq.EventEntityUser, "created", user.ID, "/users/"+user.ID, user)
+	writeJSON(w, http.StatusCreated, user)
+}
+
+func (s *Server) user(w http.ResponseWriter, r *http.Request, userID string) {
+	for i := range s.state.users {
+		user := &s.state.users[i]
+		if user.ID != userID {
+			continue
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, user)
+		case http.MethodPost:
+			var params basiq.UserParams
+			if !decodeBody(w, r, &params) {
+				return
+			}
+			user.Email = override(user.Email, params.Email)
+			user.Mobile = override(user.Mobile, params.Mobile)
+			user.FirstName = override(user.FirstName, params.FirstName)
+			user.LastName = override(user.LastName, params.LastName)
+			user.Name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+			s.addEvent(basiq.EventEntityUser, "updated", user.ID, "/users/"+user.ID, *user)
+			writeJSON(w, http.StatusOK, user)
+		case http.MethodDelete:
+			s.addEvent(basiq.EventEntityUser, "deleted", user.ID, "/users/"+user.ID, *user)
+			s.state.users = append(s.state.users[:i], s.state.users[i+1:]...)
+			delete(s.state.connections, userID)
+			delete(s.state.accounts, userID)
+			delete(s.state.transactions, userID)
+			delete(s.state.consents, userID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+		}
+		return
+	}
+}
+
+func (s *Server) connections(w http.ResponseWriter, r *http.Request, userID string, path []string) {
+	connections := s.state.connections[userID]
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, basiq.ConnectionList{
+			Type:  "list",
+			Data:  nonNil(connections),
+			Links: basiq.SelfLink{Self: s.link("users", userID, "connections")},
+		})
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var params connectionParams
+		if !decodeBody(w, r, &params) || !params.validate(w, true) {
+			return
+		}
+		connection := s.addConnection(userID, basiq.Connection{
+			Method:      "web",
+			Institution: basiq.InstitutionRef{Type: "institution", ID: params.Institution.ID},
+		})
+		s.addEvent(basiq.EventEntityConnection, "created", userID, "/users/"+userID+"/connections/"+connection.ID, connection)
+		writeJSON(w, http.StatusAccepted, s.refreshJob(userID, connection))
+	case len(path) == 1 && path[0] == "refresh" && r.Method == http.MethodPost:
+		jobs := make([]basiq.Job, 0, len(connections))
+		for _, connection := range connections {
+			jobs = append(jobs, s.refreshJob(userID, connection))
+		}
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"type": "list", "data": jobs})
+	case len(path) >= 1:
+		for i, connection := range connections {
+			if connection.ID != path[0] {
+				continue
+			}
+			switch {
+			case len(path) == 1 && r.Method == http.MethodGet:
+				writeJSON(w, http.StatusOK, connection)
+			case len(path) == 1 && r.Method == http.MethodPost:
+				var params connectionParams
+				if !decodeBody(w, r, &params) || !params.validate(w, false) {
+					return
+				}
+				writeJSON(w, http.StatusAccepted, s.addJob(userID, connection.Links.Self, basiq.JobStepVerifyCredentials))
+			case len(path) == 1 && r.Method == http.MethodDelete:
+				s.state.connections[userID] = append(connections[:i:i], connections[i+1:]...)
+				s.addEvent(basiq.EventEntityConnection, "deleted", userID, "/users/"+userID+"/connections/"+connection.ID, connection)
+				w.WriteHeader(http.StatusNoContent)
+			case len(path) == 2 && path[1] == "refresh" && r.Method == http.MethodPost:
+				writeJSON(w, http.StatusAccepted, s.refreshJob(userID, connection))
+			default:
+				writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+			}
+			return
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Connection not found")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+	}
+}
+
+// connectionParams are the credentials sent to create or update the connection.
+type connectionParams struct {
+	LoginID     string `json:"loginId"`
+	Password    string `json:"password"`
+	Institution struct {
+		ID string `json:"id"`
+	} `json:"institution"`
+}
+
+func (p connectionParams) validate(w http.ResponseWriter, withInstitution bool) bool {
+	switch {
+	case p.LoginID == "" || p.Password == "":
+		writeError(w, http.StatusBadRequest, "parameter-not-supplied", "loginId and password are required")
+		return false
+	case withInstitution && p.Institution.ID == "":
+		writeError(w, http.StatusBadRequest, "parameter-not-supplied", "institution.id is required")
+		return false
+	default:
+		return true
+	}
+}
+
+func (s *Server) refreshJob(userID string, connection basiq.Connection) basiq.Job {
+	return s.addJob(userID, s.link("users", userID, "connections", connection.ID),
+		basiq.JobStepVerifyCredentials, basiq.JobStepRetrieveAccounts, basiq.JobStepRetrieveTransactions)
+}
+
+// uploadStatement creates the connection of the institution from the statement, the statement itself is not parsed.
+func (s *Server) uploadStatement(w http.ResponseWriter, r *http.Request, userID string) {
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		writeError(w, http.StatusBadRequest, "parameter-not-valid", err.Error())
+		return
+	}
+	institutionID := r.FormValue("institutionId")
+	if _, _, err := r.FormFile("statement"); err != nil || institutionID == "" {
+		writeError(w, http.StatusBadRequest, "parameter-not-supplied", "institutionId and statement are required")
+		return
+	}
+
+	connection := s.addConnection(userID, basiq.Connection{
+		Method:      "statement",
+		Institution: basiq.InstitutionRef{Type: "institution", ID: institutionID},
+	})
+	s.addEvent(basiq.EventEntityConnection, "created", userID, "/users/"+userID+"/connections/"+connection.ID, connection)
+	writeJSON(w, http.StatusAccepted, s.addJob(userID, connection.Links.Self,
+		basiq.JobStepRetrieveAccounts, basiq.JobStepRetrieveTransactions))
+}
+
+func (s *Server) accounts(w http.ResponseWriter, userID string, path []string) {
+	accounts := s.state.accounts[userID]
+	if len(path) == 0 {
+		writeJSON(w, http.StatusOK, basiq.AccountList{
+			Type:  "list",
+			Data:  nonNil(accounts),
+			Links: basiq.SelfLink{Self: s.link("users", userID, "accounts")},
+		})
+		return
+	}
+	for _, account := range accounts {
+		if len(path) == 1 && account.ID == path[0] {
+			writeJSON(w, http.StatusOK, account)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "resource-not-found", "Account not found")
+}
+
+// transactions lists the transactions of the user, the "account.id" and "connection.id" filters are supported.
+func (s *Server) transactions(w http.ResponseWriter, r *http.Request, userID string, path []string) {
+	transactions := s.state.transactions[userID]
+	if len(path) == 1 {
+		for _, transaction := range transactions {
+			if transaction.ID == path[0] {
+				writeJSON(w, http.StatusOK, transaction)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Transaction not found")
+		return
+	}
+
+	filters := parseFilter(r.URL.Query().Get("filter"))
+	var filtered []basiq.Transaction
+	for _, transaction := range transactions {
+		if matchFilter(filters, "account.id", transaction.Account) &&
+			matchFilter(filters, "connection.id", transaction.Connection) {
+			filtered = append(filtered, transaction)
+		}
+	}
+
+	page, links := s.paginate(r, len(filtered))
+	writeJSON(w, http.StatusOK, basiq.TransactionList{
+		Type:  "list",
+		Count: len(filtered),
+		Size:  page.end - page.start,
+		Data:  nonNil(filtered[page.start:page.end]),
+		Links: links,
+	})
+}
+
+func (s *Server) userJobs(w http.ResponseWriter, userID string) {
+	userJobs := []basiq.UserJob{}
+	for _, job := range s.state.jobs {
+		if s.state.jobUsers[job.ID] != userID {
+			continue
+		}
+		userJobs = append(userJobs, basiq.UserJob{
+			Type:    job.Type,
+			ID:      job.ID,
+			Created: job.Created,
+			Updated: job.Updated,
+			Steps:   job.Steps,
+			Links:   job.Links,
+		})
+	}
+	writeJSON(w, http.StatusOK, basiq.UserJobList{
+		Type:  "list",
+		Size:  len(userJobs),
+		Data:  userJobs,
+		Links: basiq.SelfLink{Self: s.link("users", userID, "jobs")},
+	})
+}
+
+func (s *Server) job(w http.ResponseWriter, jobID string) {
+	for _, job := range s.state.jobs {
+		if job.ID == jobID {
+			writeJSON(w, http.StatusOK, job)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "resource-not-found", "Job not found")
+}
+
+// jobMFA accepts the MFA response, the step waiting for it succeeds.
+func (s *Server) jobMFA(w http.ResponseWriter, r *http.Request, jobID string) {
+	var params basiq.MFAParams
+	if !decodeBody(w, r, &params) {
+		return
+	}
+
+	for i := range s.state.jobs {
+		job := &s.state.jobs[i]
+		if job.ID != jobID {
+			continue
+		}
+		step, ok := job.MFAStep()
+		if !ok {
+			writeError(w, http.StatusBadRequest, "parameter-not-valid", "Job doesn't wait for MFA response")
+			return
+		}
+		for j := range job.Steps {
+			if job.Steps[j].Title == step.Title {
+				job.Steps[j].Status = basiq.JobStepStatusSuccess
+				job.Steps[j].Result = basiq.JobStepResult{}
+			}
+		}
+		job.Updated = s.now()
+		writeJSON(w, http.StatusOK, basiq.MFA{Type: "job", ID: job.ID, Links: basiq.SelfLink{Self: job.Links.Self}})
+		return
+	}
+	writeError(w, http.StatusNotFound, "resource-not-found", "Job not found")
+}
+
+func (s *Server) consents(w http.ResponseWriter, r *http.Request, userID string, path []string) {
+	consents := s.state.consents[userID]
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, basiq.UserConsentList{
+			Type:  "list",
+			Size:  len(consents),
+			Data:  nonNil(consents),
+			Links: basiq.SelfLink{Self: s.link("users", userID, "consents")},
+		})
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		for i := range consents {
+			if consents[i].ID == path[0] {
+				consents[i].Status = basiq.ConsentStatusRevoked
+				consents[i].Updated = s.now()
+				s.addEvent(basiq.EventEntityConsent, "revoked", userID, "/users/"+userID+"/consents/"+consents[i].ID, consents[i])
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Consent not found")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+	}
+}
+
+func (s *Server) affordability(w http.ResponseWriter, r *http.Request, userID string, path []string) {
+	snapshots := s.state.affordability[userID]
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		summaries := make([]basiq.AffordabilitySummary, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			summaries = append(summaries, basiq.AffordabilitySummary{
+				Type:          snapshot.Type,
+				ID:            snapshot.ID,
+				CoverageDays:  snapshot.CoverageDays,
+				FromMonth:     snapshot.FromMonth,
+				ToMonth:       snapshot.ToMonth,
+				GeneratedDate: snapshot.GeneratedDate,
+				Links:         snapshot.Links,
+			})
+		}
+		writeJSON(w, http.StatusOK, basiq.AffordabilitySummaryList{
+			Type:  "list",
+			Data:  summaries,
+			Links: basiq.SelfLink{Self: s.link("users", userID, "affordability")},
+		})
+	case len(path) == 0 && r.Method == http.MethodPost:
+		params, ok := decodeSnapshotParams(w, r)
+		if !ok {
+			return
+		}
+		snapshot := basiq.Affordability{
+			Type:          "affordability",
+			ID:            s.nextID("affordability"),
+			CoverageDays:  params.coverageDays(),
+			FromMonth:     params.FromMonth,
+			ToMonth:       params.ToMonth,
+			GeneratedDate: s.now(),
+			Assets:        []basiq.AffordabilityAsset{},
+			External:      []basiq.ExternalIncome{},
+		}
+		snapshot.Links = s.snapshotLinks(userID, "affordability", snapshot.ID)
+		s.state.affordability[userID] = append(snapshots, snapshot)
+		writeJSON(w, http.StatusCreated, snapshot)
+	case len(path) >= 1 && r.Method == http.MethodGet:
+		for _, snapshot := range snapshots {
+			if snapshot.ID != path[0] {
+				continue
+			}
+			if len(path) == 2 && path[1] == "transactions" {
+				s.affordabilityTransactions(w, r, userID, snapshot)
+				return
+			}
+			writeJSON(w, http.StatusOK, snapshot)
+			return
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Affordability snapshot not found")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+	}
+}
+
+// affordabilityTransactions lists the transactions of the user posted within the months of the snapshot.
+func (s *Server) affordabilityTransactions(w http.ResponseWriter, r *http.Request, userID string, snapshot basiq.Affordability) {
+	from, to := snapshot.FromMonth.First(), snapshot.ToMonth.Last()
+
+	var transactions []basiq.AffordabilityTransaction
+	for _, t := range s.state.transactions[userID] {
+		if t.PostDate.Before(from.Time) || t.PostDate.After(to.Time) {
+			continue
+		}
+		transactions = append(transactions, basiq.AffordabilityTransaction{
+			Type:            t.Type,
+			ID:              t.ID,
+			Account:         t.Account,
+			Amount:          t.Amount,
+			Balance:         t.Balance,
+			Class:           t.Class,
+			Description:     t.Description,
+			Direction:       t.Direction,
+			Institution:     t.Institution,
+			PostDate:        t.PostDate,
+			Status:          t.Status,
+			TransactionDate: t.TransactionDate,
+			Links:           t.Links,
+		})
+	}
+
+	page, links := s.paginate(r, len(transactions))
+	writeJSON(w, http.StatusOK, basiq.AffordabilityTransactionList{
+		Type:  "list",
+		Count: len(transactions),
+		Size:  page.end - page.start,
+		Data:  nonNil(transactions[page.start:page.end]),
+		Links: links,
+	})
+}
+
+func (s *Server) income(w http.ResponseWriter, r *http.Request, userID string, path []string) {
+	summaries := s.state.income[userID]
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		snapshots := make([]basiq.IncomeSummaryItem, 0, len(summaries))
+		for _, summary := range summaries {
+			snapshots = append(snapshots, basiq.IncomeSummaryItem{
+				Type:          summary.Type,
+				ID:            summary.ID,
+				CoverageDays:  summary.CoverageDays,
+				FromMonth:     summary.FromMonth,
+				ToMonth:       summary.ToMonth,
+				GeneratedDate: summary.GeneratedDate,
+				Links:         summary.Links,
+			})
+		}
+		writeJSON(w, http.StatusOK, basiq.IncomeSummaryItemList{
+			Type:  "list",
+			Data:  snapshots,
+			Links: basiq.SelfLink{Self: s.link("users", userID, "income")},
+		})
+	case len(path) == 0 && r.Method == http.MethodPost:
+		params, ok := decodeSnapshotParams(w, r)
+		if !ok {
+			return
+		}
+		summary := basiq.IncomeSummary{
+			Type:          "income",
+			ID:            s.nextID("income"),
+			CoverageDays:  params.coverageDays(),
+			FromMonth:     params.FromMonth,
+			ToMonth:       params.ToMonth,
+			GeneratedDate: s.now(),
+			Regular:       []basiq.RegularIncome{},
+			Irregular:     []basiq.IrregularIncome{},
+			OtherCredit:   []basiq.IrregularIncome{},
+		}
+		summary.Links = s.snapshotLinks(userID, "income", summary.ID)
+		s.state.income[userID] = append(summaries, summary)
+		writeJSON(w, http.StatusCreated, summary)
+	case len(path) == 1 && r.Method == http.MethodGet:
+		for _, summary := range summaries {
+			if summary.ID == path[0] {
+				writeJSON(w, http.StatusOK, summary)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Income summary not found")
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		for i, summary := range summaries {
+			if summary.ID == path[0] {
+				s.state.income[userID] = append(summaries[:i], summaries[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Income summary not found")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+	}
+}
+
+func (s *Server) expenses(w http.ResponseWriter, r *http.Request, userID string, path []string) {
+	summaries := s.state.expenses[userID]
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		snapshots := make([]basiq.ExpenseSummaryItem, 0, len(summaries))
+		for _, summary := range summaries {
+			snapshots = append(snapshots, basiq.ExpenseSummaryItem{
+				Type:          summary.Type,
+				ID:            summary.ID,
+				CoverageDays:  summary.CoverageDays,
+				FromMonth:     summary.FromMonth,
+				ToMonth:       summary.ToMonth,
+				GeneratedDate: summary.GeneratedDate,
+				Links:         summary.Links,
+			})
+		}
+		writeJSON(w, http.StatusOK, basiq.ExpenseSummaryItemList{
+			Type:  "list",
+			Data:  snapshots,
+			Links: basiq.SelfLink{Self: s.link("users", userID, "expenses")},
+		})
+	case len(path) == 0 && r.Method == http.MethodPost:
+		params, ok := decodeSnapshotParams(w, r)
+		if !ok {
+			return
+		}
+		summary := basiq.ExpenseSummary{
+			Type:          "expenses",
+			ID:            s.nextID("expenses"),
+			CoverageDays:  params.coverageDays(),
+			FromMonth:     params.FromMonth,
+			ToMonth:       params.ToMonth,
+			GeneratedDate: s.now(),
+			Payments:      []basiq.ExpensePayment{},
+		}
+		summary.Links = s.snapshotLinks(userID, "expenses", summary.ID)
+		s.state.expenses[userID] = append(summaries, summary)
+		writeJSON(w, http.StatusCreated, summary)
+	case len(path) == 1 && r.Method == http.MethodGet:
+		for _, summary := range summaries {
+			if summary.ID == path[0] {
+				writeJSON(w, http.StatusOK, summary)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Expense summary not found")
+	case len(path) == 1 && r.Method == http.MethodDelete:
+		for i, summary := range summaries {
+			if summary.ID == path[0] {
+				s.state.expenses[userID] = append(summaries[:i], summaries[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Expense summary not found")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+	}
+}
+
+func (s *Server) snapshotLinks(userID, resource, snapshotID string) basiq.SnapshotLinks {
+	return basiq.SnapshotLinks{Self: s.link("users", userID, resource, snapshotID)}
+}
+
+// institutions filters the institutions exactly by the fields of basiq.InstitutionParams.
+func (s *Server) institutions(w http.ResponseWriter, r *http.Request) {
+	filters := parseFilter(r.URL.Query().Get("filter"))
+
+	var institutions []basiq.Institution
+	for _, institution := range s.state.institutions {
+		if matchFilter(filters, "institution.country", institution.Country) &&
+			matchFilter(filters, "institution.tier", string(institution.Tier)) &&
+			matchFilter(filters, "institution.authorization", string(institution.Authorization)) &&
+			matchFilter(filters, "institution.status", string(institution.Status)) {
+			institutions = append(institutions, institution)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, basiq.InstitutionList{
+		Type:       "list",
+		TotalCount: len(institutions),
+		Data:       nonNil(institutions),
+		Links:      basiq.SelfLink{Self: s.link("institutions")},
+	})
+}
+
+func (s *Server) institution(w http.ResponseWriter, institutionID string) {
+	for _, institution := range s.state.institutions {
+		if institution.ID == institutionID {
+			writeJSON(w, http.StatusOK, institution)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "resource-not-found", "Institution not found")
+}
+
+func (s *Server) enrich(w http.ResponseWriter, r *http.Request) {
+	description := r.URL.Query().Get("q")
+	enrichment, ok := s.state.enrichments[description]
+	if !ok {
+		writeError(w, http.StatusNotFound, "resource-not-found", "No enrichment found for the description")
+		return
+	}
+	writeJSON(w, http.StatusOK, basiq.EnrichResponse{
+		Type:  "enrich",
+		Data:  enrichment,
+		Links: basiq.SelfLink{Self: s.URL + r.URL.RequestURI()},
+	})
+}
+
+func (s *Server) payRequests(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		page, links := s.paginate(r, len(s.state.payRequests))
+		writeJSON(w, http.StatusOK, basiq.PayRequestList{
+			Type:  "list",
+			Count: len(s.state.payRequests),
+			Size:  page.end - page.start,
+			Data:  nonNil(s.state.payRequests[page.start:page.end]),
+			Links: links,
+		})
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var params basiq.PayRequestParams
+		if !decodeBody(w, r, &params) {
+			return
+		}
+		jobs := make([]basiq.PayRequestJob, 0, len(params.PayRequests))
+		for _, item := range params.PayRequests {
+			payRequest := basiq.PayRequest{
+				Type:        "payrequest",
+				ID:          s.nextID("payrequest"),
+				RequestID:   item.RequestID,
+				Created:     s.now(),
+				Method:      "direct-debit",
+				Status:      basiq.PaymentStatusPending,
+				Payer:       item.Payer,
+				Description: item.Description,
+				Amount:      item.Amount,
+				Currency:    "AUD",
+			}
+			payRequest.Updated = payRequest.Created
+			job := s.addJob("", s.link("payments", "payrequests", payRequest.ID))
+			payRequest.Links = basiq.JobLinks{Self: s.link("payments", "payrequests", payRequest.ID), Job: job.Links.Self}
+			s.state.payRequests = append(s.state.payRequests, payRequest)
+			jobs = append(jobs, basiq.PayRequestJob{
+				Type:      "job",
+				ID:        job.ID,
+				RequestID: item.RequestID,
+				Links:     basiq.SelfLink{Self: job.Links.Self},
+			})
+		}
+		writeJSON(w, http.StatusAccepted, basiq.PayRequestJobList{Jobs: jobs})
+	case len(path) == 1 && r.Method == http.MethodGet:
+		for _, payRequest := range s.state.payRequests {
+			if payRequest.ID == path[0] {
+				writeJSON(w, http.StatusOK, payRequest)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Pay request not found")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+	}
+}
+
+func (s *Server) payouts(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		page, links := s.paginate(r, len(s.state.payouts))
+		writeJSON(w, http.StatusOK, basiq.PayoutList{
+			Type:  "list",
+			Count: len(s.state.payouts),
+			Size:  page.end - page.start,
+			Data:  nonNil(s.state.payouts[page.start:page.end]),
+			Links: links,
+		})
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var params basiq.PayoutParams
+		if !decodeBody(w, r, &params) {
+			return
+		}
+		payout := basiq.Payout{
+			Type:        "payout",
+			ID:          s.nextID("payout"),
+			RequestID:   params.RequestID,
+			Created:     s.now(),
+			Method:      override("npp", params.Method),
+			Status:      basiq.PaymentStatusPending,
+			Payee:       params.Payee,
+			Description: params.Description,
+			Amount:      strconv.Itoa(params.Amount),
+			Currency:    "AUD",
+		}
+		payout.Updated = payout.Created
+		job := s.addJob("", s.link("payments", "payouts", payout.ID))
+		payout.Links = basiq.JobLinks{Self: s.link("payments", "payouts", payout.ID), Job: job.Links.Self}
+		s.state.payouts = append(s.state.payouts, payout)
+		writeJSON(w, http.StatusAccepted, basiq.PayoutJobList{Jobs: []basiq.PayoutJob{{
+			Type:      "job",
+			ID:        job.ID,
+			RequestID: params.RequestID,
+			Links:     basiq.SelfLink{Self: job.Links.Self},
+		}}})
+	case len(path) == 1 && r.Method == http.MethodGet:
+		for _, payout := range s.state.payouts {
+			if payout.ID == path[0] {
+				writeJSON(w, http.StatusOK, payout)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Payout not found")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+	}
+}
+
+func (s *Server) floatAccounts(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) == 0 {
+		writeJSON(w, http.StatusOK, basiq.FloatAccountList{
+			Type:  "list",
+			Count: len(s.state.floatAccounts),
+			Size:  len(s.state.floatAccounts),
+			Data:  nonNil(s.state.floatAccounts),
+			Links: basiq.SelfLink{Self: s.link("payments", "float-accounts")},
+		})
+		return
+	}
+	for _, floatAccount := range s.state.floatAccounts {
+		if floatAccount.ID != path[0] {
+			continue
+		}
+		switch {
+		case len(path) == 1:
+			writeJSON(w, http.StatusOK, floatAccount)
+		case len(path) == 2 && path[1] == "transactions":
+			transactions := s.state.floatTransactions[floatAccount.ID]
+			page, links := s.paginate(r, len(transactions))
+			writeJSON(w, http.StatusOK, basiq.FloatAccountTransactionList{
+				Type:  "list",
+				Count: len(transactions),
+				Size:  page.end - page.start,
+				Data:  nonNil(transactions[page.start:page.end]),
+				Links: links,
+			})
+		default:
+			writeError(w, http.StatusNotFound, "resource-not-found", "Resource not found")
+		}
+		return
+	}
+	writeError(w, http.StatusNotFound, "resource-not-found", "Float account not found")
+}
+
+// events lists the recorded events, the "event.entity", "event.type" and "user.id" filters and the
+// "event.createdDate.gteq" filter are supported.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	filters := parseFilter(r.URL.Query().Get("filter"))
+
+	var events []basiq.Event
+	for _, event := range s.state.events {
+		if matchFilter(filters, "event.entity", string(event.Entity)) &&
+			matchFilter(filters, "event.type", event.EventType) &&
+			matchFilter(filters, "user.id", event.UserId) &&
+			matchSince(filters, "event.createdDate", event.CreatedDate) {
+			events = append(events, event)
+		}
+	}
+
+	page, links := s.paginate(r, len(events))
+	writeJSON(w, http.StatusOK, basiq.EventList{
+		Type:  "list",
+		Data:  nonNil(events[page.start:page.end]),
+		Links: links,
+	})
+}
+
+func (s *Server) event(w http.ResponseWriter, eventID string) {
+	for _, event := range s.state.events {
+		if event.ID == eventID {
+			writeJSON(w, http.StatusOK, event)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "resource-not-found", "Event not found")
+}
+
+// reports generates the reports immediately, the returned job has already finished and links the report.
+func (s *Server) reports(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		page, links := s.paginate(r, len(s.state.reports))
+		writeJSON(w, http.StatusOK, basiq.ReportList{
+			Type:  "list",
+			Data:  nonNil(s.state.reports[page.start:page.end]),
+			Links: links,
+		})
+	case len(path) == 0 && r.Method == http.MethodPost:
+		var params struct {
+			ReportType basiq.ReportTemplate `json:"reportType"`
+			Title      string               `json:"title"`
+			Filters    []basiq.ReportFilter `json:"filters"`
+		}
+		if !decodeBody(w, r, &params) {
+			return
+		}
+
+		var userIDs []string
+		for _, filter := range params.Filters {
+			if filter.Name == basiq.ReportFilterUsers {
+				userIDs = filter.Value
+			}
+		}
+		switch {
+		case params.ReportType == "":
+			writeError(w, http.StatusBadRequest, "parameter-not-supplied", "Report type is required")
+			return
+		case len(userIDs) == 0:
+			writeError(w, http.StatusBadRequest, "parameter-not-supplied", "Users filter is required")
+			return
+		}
+		for _, userID := range userIDs {
+			if !s.userExists(userID) {
+				writeError(w, http.StatusNotFound, "resource-not-found", "User not found")
+				return
+			}
+		}
+
+		report := basiq.Report{
+			Type:        "report",
+			ID:          s.nextID("report"),
+			ReportType:  params.ReportType,
+			Title:       params.Title,
+			CreatedDate: s.now(),
+			Filters:     params.Filters,
+			Data:        json.RawMessage(`{}`),
+		}
+		report.Links.Self = s.link("reports", report.ID)
+		s.state.reports = append(s.state.reports, report)
+		writeJSON(w, http.StatusAccepted, s.addJob(userIDs[0], report.Links.Self, "generate-report"))
+	case len(path) == 1 && r.Method == http.MethodGet:
+		for _, report := range s.state.reports {
+			if report.ID == path[0] {
+				writeJSON(w, http.StatusOK, report)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "resource-not-found", "Report not found")
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed")
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+type page struct {
+	start int
+	end   int
+}
+
+// paginate returns the bounds of the requested page and its links, the page is selected by the "offset" query
+// parameter and the other query parameters are kept in the next link.
+func (s *Server) paginate(r *http.Request, total int) (page, basiq.PageLinks) {
+	size := s.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+
+	query := r.URL.Query()
+	start, _ := strconv.Atoi(query.Get("offset"))
+	if start < 0 || start > total {
+		start = total
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+
+	links := basiq.PageLinks{Self: s.URL + r.URL.RequestURI()}
+	if end < total {
+		query.Set("offset", strconv.Itoa(end))
+		links.Next = s.URL + r.URL.Path + "?" + query.Encode()
+	}
+	return page{start: start, end: end}, links
+}
+
+// snapshotParams decodes the month range of the affordability, income and expense requests.
+type snapshotParams struct {
+	Accounts  []string    `json:"accounts"`
+	FromMonth basiq.Month `json:"fromMonth"`
+	ToMonth   basiq.Month `json:"toMonth"`
+}
+
+func decodeSnapshotParams(w http.ResponseWriter, r *http.Request) (snapshotParams, bool) {
+	var params snapshotParams
+	if !decodeBody(w, r, &params) {
+		return params, false
+	}
+	if params.ToMonth.IsZero() {
+		params.ToMonth = basiq.MonthOf(Epoch)
+	}
+	if params.FromMonth.IsZero() {
+		params.FromMonth = params.ToMonth.AddMonths(-11)
+	}
+	if params.FromMonth.After(params.ToMonth.Time) {
+		writeError(w, http.StatusBadRequest, "parameter-not-valid", "fromMonth can't be after toMonth")
+		return params, false
+	}
+	return params, true
+}
+
+func (p snapshotParams) coverageDays() int {
+	return int(p.ToMonth.Last().Sub(p.FromMonth.First().Time).Hours()/24) + 1
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "parameter-not-valid", err.Error())
+		return false
+	}
+	return true
+}
+
+// parseFilter parses the Basiq filter expression, e.g. "event.entity.eq('user'),user.id.eq('123')". Fields of other
+// operators than "eq" are keyed with the operator, e.g. "event.createdDate.gteq".
+func parseFilter(filter string) map[string]string {
+	filters := map[string]string{}
+	for _, match := range filterPattern.FindAllStringSubmatch(filter, -1) {
+		field := match[1]
+		if match[2] != "eq" {
+			field += "." + match[2]
+		}
+		filters[field] = match[3]
+	}
+	return filters
+}
+
+func matchFilter(filters map[string]string, field, value string) bool {
+	expected, ok := filters[field]
+	return !ok || expected == value
+}
+
+// matchSince matches the "gteq" filter of the timestamp field, unparsable values don't match anything.
+func matchSince(filters map[string]string, field string, value basiq.Timestamp) bool {
+	since, ok := filters[field+".gteq"]
+	if !ok {
+		return true
+	}
+	t, err := time.Parse(time.RFC3339Nano, since)
+	return err == nil && !value.Before(t)
+}
+
+func override(value, with string) string {
+	if with == "" {
+		return value
+	}
+	return with
+}
+
+// nonNil makes the empty lists encode as [] instead of null.
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
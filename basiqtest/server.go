@@ -0,0 +1,234 @@
+// Package basiqtest provides an in-process fake of the Basiq API for tests. The server keeps its state in memory,
+// generates deterministic IDs and timestamps and allows injecting errors and latency.
+//
+//	srv := basiqtest.NewServer()
+//	defer srv.Close()
+//
+//	api, err := basiq.NewAPI(srv.Config())
+//
+// The Recorder and the Replayer capture the traffic of the real API into cassette files and serve it back offline.
+package basiqtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+const (
+	// APIKey is the only API key the token endpoint of the server accepts.
+	APIKey = "basiqtest-api-key"
+
+	defaultPageSize = 100
+)
+
+// Epoch is the timestamp of the first resource created by the server, every following resource is one second newer.
+var Epoch = time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Server is the fake Basiq API. All methods are safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	// PageSize limits the number of items of the paginated lists.
+	PageSize int
+
+	m       sync.Mutex
+	seq     int
+	tokens  map[string]bool
+	latency time.Duration
+	faults  []*fault
+	state   state
+}
+
+type fault struct {
+	method string
+	path   string
+	status int
+	body   errorBody
+	times  int
+}
+
+// errorBody is the error response of Basiq, basiq.Error can't be used as it encodes the HTTP code into the body.
+type errorBody struct {
+	Type          string            `json:"type"`
+	CorrelationID string            `json:"correlationId"`
+	Data          []basiq.ErrorData `json:"data"`
+}
+
+// NewServer starts the server, it has to be closed by the caller.
+func NewServer() *Server {
+	s := &Server{
+		PageSize: defaultPageSize,
+		tokens:   map[string]bool{},
+		state:    newState(),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Config returns the client configuration pointing to the server.
+func (s *Server) Config() basiq.Config {
+	return basiq.Config{
+		APIKey:     APIKey,
+		Scope:      basiq.ServerScope,
+		BaseURL:    s.URL,
+		HTTPClient: s.Client(),
+	}
+}
+
+// SetLatency delays every response of the server by the given duration.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.latency = latency
+}
+
+// InjectError makes the next times requests matching the method and the path fail with the given status.
+// The path can contain "*" segments matching any single segment, e.g. "/users/*/accounts". Empty method matches
+// all methods, times lower than 1 makes the fault permanent.
+func (s *Server) InjectError(method, path string, status, times int) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.faults = append(s.faults, &fault{
+		method: method,
+		path:   path,
+		status: status,
+		times:  times,
+		body: errorBody{
+			Type:          "list",
+			CorrelationID: s.nextID("correlation"),
+			Data: []basiq.ErrorData{{
+				Type:   "error",
+				Code:   "injected-error",
+				Title:  http.StatusText(status),
+				Detail: "Error injected by basiqtest",
+			}},
+		},
+	})
+}
+
+// ClearErrors removes all injected errors.
+func (s *Server) ClearErrors() {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.faults = nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.m.Lock()
+	latency := s.latency
+	f := s.matchFault(r)
+	s.m.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if f != nil {
+		writeJSON(w, f.status, f.body)
+		return
+	}
+
+	if r.URL.Path != "/token" && !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "unauthorized-access", "Access token is missing or invalid")
+		return
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.route(w, r)
+}
+
+func (s *Server) matchFault(r *http.Request) *fault {
+	for i, f := range s.faults {
+		if f.method != "" && f.method != r.Method || !matchPath(f.path, r.URL.Path) {
+			continue
+		}
+		if f.times > 0 {
+			f.times--
+			if f.times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.tokens[token]
+}
+
+// nextID returns a deterministic ID with the given prefix.
+func (s *Server) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-%04d", prefix, s.seq)
+}
+
+// now returns a deterministic timestamp, which grows with every created resource.
+func (s *Server) now() basiq.Timestamp {
+	return basiq.NewTimestamp(Epoch.Add(time.Duration(s.seq) * time.Second))
+}
+
+func matchPath(pattern, path string) bool {
+	patternSegments := segments(pattern)
+	pathSegments := segments(path)
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+	for i := range patternSegments {
+		if patternSegments[i] != "*" && patternSegments[i] != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func segments(path string) []string {
+	var result []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			result = append(result, segment)
+		}
+	}
+	return result
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, detail string) {
+	writeJSON(w, status, errorBody{
+		Type: "list",
+		Data: []basiq.ErrorData{{
+			Type:   "error",
+			Code:   code,
+			Title:  http.StatusText(status),
+			Detail: detail,
+		}},
+	})
+}
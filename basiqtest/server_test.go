@@ -0,0 +1,384 @@
+package basiqtest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukasaron/basiq-go"
+	"github.com/lukasaron/basiq-go/basiqtest"
+)
+
+// newAPI starts the fake server and returns the authenticated client calling it, so the first call of the test
+// isn't retried after 401. The server is closed with the test.
+func newAPI(t *testing.T) (*basiq.API, *basiqtest.Server) {
+	t.Helper()
+
+	srv := basiqtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	api, err := basiq.NewAPI(srv.Config())
+	if err != nil {
+		t.Fatalf("NewAPI: %v", err)
+	}
+	if err = api.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Authenticate: %v", err)
+	}
+	return api, srv
+}
+
+func httpCode(err error) int {
+	var e *basiq.Error
+	if !errors.As(err, &e) {
+		return 0
+	}
+	return e.HttpCode
+}
+
+func TestServerUsers(t *testing.T) {
+	api, _ := newAPI(t)
+	ctx := context.Background()
+
+	user, err := api.CreateUser(ctx, basiq.UserParams{Email: "jane@example.com", FirstName: "Jane"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if user.ID == "" || user.Email != "jane@example.com" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+
+	updated, err := api.UpdateUser(ctx, user.ID, basiq.UserParams{Mobile: "+61400000000"})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if updated.Mobile != "+61400000000" || updated.Email != user.Email {
+		t.Errorf("unexpected updated user %+v", updated)
+	}
+
+	found, err := api.FindUsers(ctx, basiq.UserParams{Email: "jane@example.com"})
+	if err != nil {
+		t.Fatalf("FindUsers: %v", err)
+	}
+	if len(found) != 1 || found[0].ID != user.ID {
+		t.Errorf("FindUsers returned %+v, want user %s", found, user.ID)
+	}
+
+	if err = api.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	if _, err = api.User(ctx, user.ID); httpCode(err) != http.StatusNotFound {
+		t.Errorf("User of the deleted user returned %v, want 404", err)
+	}
+}
+
+func TestServerReauthenticatesAfterUnauthorized(t *testing.T) {
+	api, srv := newAPI(t)
+	user := srv.AddUser(basiq.User{Email: "jane@example.com"})
+
+	srv.InjectError(http.MethodGet, "/users/"+user.ID, http.StatusUnauthorized, 1)
+	got, err := api.User(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("User: %v", err)
+	}
+	if got.ID != user.ID {
+		t.Errorf("User returned %s, want %s", got.ID, user.ID)
+	}
+
+	srv.InjectError(http.MethodGet, "/users/"+user.ID, http.StatusServiceUnavailable, 1)
+	if _, err = api.User(context.Background(), user.ID); httpCode(err) != http.StatusServiceUnavailable {
+		t.Errorf("User returned %v, want 503", err)
+	}
+}
+
+func TestServerConnectionsAndJobs(t *testing.T) {
+	api, srv := newAPI(t)
+	ctx := context.Background()
+
+	user := srv.AddUser(basiq.User{Email: "jane@example.com"})
+	connection := srv.AddConnection(user.ID, basiq.Connection{})
+
+	connections, err := api.Connections(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Connections: %v", err)
+	}
+	if len(connections) != 1 || connections[0].ID != connection.ID {
+		t.Fatalf("Connections returned %+v, want connection %s", connections, connection.ID)
+	}
+
+	refreshed, err := api.RefreshConnection(ctx, user.ID, connection.ID)
+	if err != nil {
+		t.Fatalf("RefreshConnection: %v", err)
+	}
+	if refreshed.ID == "" {
+		t.Fatalf("RefreshConnection returned no job %+v", refreshed)
+	}
+
+	jobs, err := api.UserJobs(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("UserJobs: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("UserJobs returned %d jobs, want 1", len(jobs))
+	}
+
+	job, err := api.WaitForJob(ctx, jobs[0].ID, basiq.JobWaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitForJob: %v", err)
+	}
+	if !job.IsSuccessful() {
+		t.Errorf("job %s hasn't succeeded: %+v", job.ID, job.Steps)
+	}
+
+	if err = api.DeleteConnection(ctx, user.ID, connection.ID); err != nil {
+		t.Fatalf("DeleteConnection: %v", err)
+	}
+	if _, err = api.Connection(ctx, user.ID, connection.ID); httpCode(err) != http.StatusNotFound {
+		t.Errorf("Connection of the deleted connection returned %v, want 404", err)
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	api, srv := newAPI(t)
+	srv.PageSize = 2
+
+	user := srv.AddUser(basiq.User{Email: "jane@example.com"})
+	account := srv.AddAccount(user.ID, basiq.Account{})
+	for i := 0; i < 5; i++ {
+		srv.AddTransactions(user.ID, basiq.Transaction{Account: account.ID, Amount: "-10.00"})
+	}
+
+	transactions, err := api.Transactions(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("Transactions: %v", err)
+	}
+	if len(transactions) != 5 {
+		t.Fatalf("Transactions returned %d transactions, want 5", len(transactions))
+	}
+	seen := map[string]bool{}
+	for _, transaction := range transactions {
+		if seen[transaction.ID] {
+			t.Errorf("transaction %s returned twice", transaction.ID)
+		}
+		seen[transaction.ID] = true
+	}
+}
+
+func TestServerMFA(t *testing.T) {
+	api, srv := newAPI(t)
+
+	job := srv.SetJob(basiq.Job{Steps: []basiq.JobStep{
+		{Title: basiq.JobStepVerifyCredentials, Status: basiq.JobStepStatusInProgress, Result: basiq.JobStepResult{
+			Type:      "mfa",
+			MFAType:   basiq.MFATypeOneTimePassword,
+			MFAPrompt: "Enter the code",
+		}},
+	}})
+
+	answers := 0
+	got, err := api.WaitForJob(context.Background(), job.ID, basiq.JobWaitOptions{
+		Interval: time.Millisecond,
+		MFA: func(context.Context, basiq.MFAChallenge) ([]string, error) {
+			answers++
+			return []string{"123456"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("WaitForJob: %v", err)
+	}
+	if !got.IsSuccessful() {
+		t.Errorf("job %s hasn't succeeded: %+v", got.ID, got.Steps)
+	}
+	if answers != 1 {
+		t.Errorf("MFA handler called %d times, want 1", answers)
+	}
+}
+
+func TestServerPayments(t *testing.T) {
+	api, srv := newAPI(t)
+	ctx := context.Background()
+
+	payer := srv.AddUser(basiq.User{Email: "payer@example.com"})
+	payRequestJobs, err := api.CreatePayRequest(ctx, basiq.PayRequestParams{PayRequests: []basiq.PayRequestItem{
+		{RequestID: "rent", Description: "Rent", Amount: 10000, Payer: basiq.Payer{PayerUserID: payer.ID}},
+		{RequestID: "bond", Description: "Bond", Amount: 40000, Payer: basiq.Payer{PayerUserID: payer.ID}},
+	}})
+	if err != nil {
+		t.Fatalf("CreatePayRequest: %v", err)
+	}
+	if len(payRequestJobs) != 2 {
+		t.Fatalf("CreatePayRequest returned %d jobs, want 2", len(payRequestJobs))
+	}
+
+	payRequests, err := api.PayRequests(ctx)
+	if err != nil {
+		t.Fatalf("PayRequests: %v", err)
+	}
+	if len(payRequests) != 2 {
+		t.Fatalf("PayRequests returned %d pay requests, want 2", len(payRequests))
+	}
+
+	payee := srv.AddUser(basiq.User{Email: "payee@example.com"})
+	payoutJobs, err := api.CreatePayout(ctx, basiq.PayoutParams{
+		RequestID:   "refund",
+		Description: "Refund",
+		Amount:      2500,
+		Payee:       basiq.Payee{PayeeUserID: payee.ID},
+	})
+	if err != nil {
+		t.Fatalf("CreatePayout: %v", err)
+	}
+	payouts, err := api.Payouts(ctx)
+	if err != nil {
+		t.Fatalf("Payouts: %v", err)
+	}
+	if len(payoutJobs) != 1 || len(payouts) != 1 {
+		t.Fatalf("CreatePayout returned %d jobs and stored %d payouts, want 1", len(payoutJobs), len(payouts))
+	}
+
+	if !srv.SetPaymentStatus(payRequests[0].ID, basiq.PaymentStatusCompleted, basiq.PaymentReason{}) {
+		t.Fatalf("pay request %s not found", payRequests[0].ID)
+	}
+	payRequest, err := api.PayRequest(ctx, payRequests[0].ID)
+	if err != nil {
+		t.Fatalf("PayRequest: %v", err)
+	}
+	if payRequest.Status != basiq.PaymentStatusCompleted {
+		t.Errorf("pay request status %s, want %s", payRequest.Status, basiq.PaymentStatusCompleted)
+	}
+
+	srv.SetPaymentStatus(payouts[0].ID, basiq.PaymentStatusFailed, basiq.PaymentReason{Title: "Rejected"})
+	payout, err := api.Payout(ctx, payouts[0].ID)
+	if err != nil {
+		t.Fatalf("Payout: %v", err)
+	}
+	if payout.Status != basiq.PaymentStatusFailed || payout.Reason.Title != "Rejected" {
+		t.Errorf("unexpected payout %+v", payout)
+	}
+}
+
+func TestServerCreateAndUpdateConnection(t *testing.T) {
+	api, srv := newAPI(t)
+	ctx := context.Background()
+
+	user := srv.AddUser(basiq.User{Email: "jane@example.com"})
+	credentials := basiq.Credentials{InstitutionID: "AU00000", LoginID: "gavinBelson", Password: []byte("hooli2016")}
+
+	job, err := api.CreateConnection(ctx, user.ID, credentials)
+	if err != nil {
+		t.Fatalf("CreateConnection: %v", err)
+	}
+	if job, err = api.WaitForJob(ctx, job.ID, basiq.JobWaitOptions{Interval: time.Millisecond}); err != nil {
+		t.Fatalf("WaitForJob: %v", err)
+	}
+
+	connections, err := api.Connections(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Connections: %v", err)
+	}
+	if len(connections) != 1 {
+		t.Fatalf("CreateConnection stored %d connections, want 1", len(connections))
+	}
+
+	if _, err = api.UpdateConnection(ctx, user.ID, connections[0].ID, credentials); err != nil {
+		t.Fatalf("UpdateConnection: %v", err)
+	}
+	jobs, err := api.UserJobs(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("UserJobs: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Errorf("UserJobs returned %d jobs, want one per call", len(jobs))
+	}
+}
+
+func TestServerUploadStatement(t *testing.T) {
+	api, srv := newAPI(t)
+	ctx := context.Background()
+
+	user := srv.AddUser(basiq.User{Email: "jane@example.com"})
+	job, err := api.UploadStatement(ctx, user.ID, basiq.StatementParams{
+		InstitutionID: "AU00000",
+		Statement:     strings.NewReader("%PDF-1.4"),
+	})
+	if err != nil {
+		t.Fatalf("UploadStatement: %v", err)
+	}
+	if _, err = api.WaitForJob(ctx, job.ID, basiq.JobWaitOptions{Interval: time.Millisecond}); err != nil {
+		t.Fatalf("WaitForJob: %v", err)
+	}
+
+	connections, err := api.Connections(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Connections: %v", err)
+	}
+	if len(connections) != 1 {
+		t.Errorf("UploadStatement stored %d connections, want 1", len(connections))
+	}
+}
+
+func TestServerReports(t *testing.T) {
+	api, srv := newAPI(t)
+	ctx := context.Background()
+
+	user := srv.AddUser(basiq.User{Email: "jane@example.com"})
+	job, err := api.CreateReport(ctx, basiq.ReportParams{
+		Template: basiq.ReportTemplateAffordability,
+		UserIDs:  []string{user.ID},
+	})
+	if err != nil {
+		t.Fatalf("CreateReport: %v", err)
+	}
+
+	report, err := api.WaitForReport(ctx, job.ID, basiq.JobWaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitForReport: %v", err)
+	}
+	if report.ReportType != basiq.ReportTemplateAffordability {
+		t.Errorf("report type %s, want %s", report.ReportType, basiq.ReportTemplateAffordability)
+	}
+
+	reports, err := api.Reports(ctx)
+	if err != nil {
+		t.Fatalf("Reports: %v", err)
+	}
+	if len(reports) != 1 || reports[0].ID != report.ID {
+		t.Errorf("Reports returned %d reports, want report %s only", len(reports), report.ID)
+	}
+}
+
+func TestServerSummarySnapshots(t *testing.T) {
+	api, srv := newAPI(t)
+	ctx := context.Background()
+
+	user := srv.AddUser(basiq.User{Email: "jane@example.com"})
+	income, err := api.CreateIncomeSummary(ctx, user.ID, basiq.IncomeSummaryParams{})
+	if err != nil {
+		t.Fatalf("CreateIncomeSummary: %v", err)
+	}
+	expense, err := api.CreateExpenseSummary(ctx, user.ID, basiq.ExpenseSummaryParams{})
+	if err != nil {
+		t.Fatalf("CreateExpenseSummary: %v", err)
+	}
+
+	if err = api.DeleteIncomeSummary(ctx, user.ID, income.ID); err != nil {
+		t.Fatalf("DeleteIncomeSummary: %v", err)
+	}
+	if err = api.DeleteExpenseSummary(ctx, user.ID, expense.ID); err != nil {
+		t.Fatalf("DeleteExpenseSummary: %v", err)
+	}
+
+	incomeItems, err := api.IncomeSummaries(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("IncomeSummaries: %v", err)
+	}
+	expenseItems, err := api.ExpenseSummaries(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("ExpenseSummaries: %v", err)
+	}
+	if len(incomeItems) != 0 || len(expenseItems) != 0 {
+		t.Errorf("%d income and %d expense snapshots left, want none", len(incomeItems), len(expenseItems))
+	}
+}
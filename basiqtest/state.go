@@ -0,0 +1,381 @@
+package basiqtest
+
+import (
+	"encoding/json"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+// state is the in-memory model of the server, all collections keep the insertion order.
+type state struct {
+	users             []basiq.User
+	connections       map[string][]basiq.Connection
+	accounts          map[string][]basiq.Account
+	transactions      map[string][]basiq.Transaction
+	consents          map[string][]basiq.UserConsent
+	affordability     map[string][]basiq.Affordability
+	income            map[string][]basiq.IncomeSummary
+	expenses          map[string][]basiq.ExpenseSummary
+	jobs              []basiq.Job
+	jobUsers          map[string]string
+	payRequests       []basiq.PayRequest
+	payouts           []basiq.Payout
+	floatAccounts     []basiq.FloatAccount
+	floatTransactions map[string][]basiq.FloatAccountTransaction
+	institutions      []basiq.Institution
+	enrichments       map[string]basiq.Enrichment
+	events            []basiq.Event
+	reports           []basiq.Report
+}
+
+func newState() state {
+	return state{
+		connections:       map[string][]basiq.Connection{},
+		accounts:          map[string][]basiq.Account{},
+		transactions:      map[string][]basiq.Transaction{},
+		consents:          map[string][]basiq.UserConsent{},
+		affordability:     map[string][]basiq.Affordability{},
+		income:            map[string][]basiq.IncomeSummary{},
+		expenses:          map[string][]basiq.ExpenseSummary{},
+		jobUsers:          map[string]string{},
+		enrichments:       map[string]basiq.Enrichment{},
+		floatTransactions: map[string][]basiq.FloatAccountTransaction{},
+	}
+}
+
+// AddUser stores the user, the ID is generated when it's empty. The stored user is returned.
+func (s *Server) AddUser(user basiq.User) basiq.User {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.addUser(user)
+}
+
+// AddConnection stores the connection of the user, the ID is generated when it's empty.
+func (s *Server) AddConnection(userID string, connection basiq.Connection) basiq.Connection {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.addConnection(userID, connection)
+}
+
+// AddAccount stores the account of the user, the ID is generated when it's empty.
+func (s *Server) AddAccount(userID string, account basiq.Account) basiq.Account {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if account.ID == "" {
+		account.ID = s.nextID("account")
+	}
+	if account.LastUpdated.IsZero() {
+		account.LastUpdated = s.now()
+	}
+	account.Type = "account"
+	account.Links = basiq.AccountLinks{
+		Self:         s.link("users", userID, "accounts", account.ID),
+		Transactions: s.link("users", userID, "transactions") + "?filter=account.id.eq('" + account.ID + "')",
+	}
+	s.state.accounts[userID] = append(s.state.accounts[userID], account)
+	s.addRef(userID, "account", account.ID, s.link("users", userID, "accounts", account.ID))
+	return account
+}
+
+// AddTransactions stores the transactions of the user, IDs are generated when they are empty.
+func (s *Server) AddTransactions(userID string, transactions ...basiq.Transaction) []basiq.Transaction {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	stored := make([]basiq.Transaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		if transaction.ID == "" {
+			transaction.ID = s.nextID("transaction")
+		}
+		if transaction.Status == "" {
+			transaction.Status = basiq.TransactionStatusPosted
+		}
+		transaction.Type = "transaction"
+		transaction.Links = basiq.TransactionLinks{
+			Self:    s.link("users", userID, "transactions", transaction.ID),
+			Account: s.link("users", userID, "accounts", transaction.Account),
+		}
+		stored = append(stored, transaction)
+	}
+	s.state.transactions[userID] = append(s.state.transactions[userID], stored...)
+	return stored
+}
+
+// AddConsent stores the consent of the user, the ID is generated when it's empty and the status defaults to active.
+func (s *Server) AddConsent(userID string, consent basiq.UserConsent) basiq.UserConsent {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if consent.ID == "" {
+		consent.ID = s.nextID("consent")
+	}
+	if consent.Status == "" {
+		consent.Status = basiq.ConsentStatusActive
+	}
+	if consent.Created.IsZero() {
+		consent.Created = s.now()
+		consent.Updated = consent.Created
+	}
+	consent.Type = "consent"
+	s.state.consents[userID] = append(s.state.consents[userID], consent)
+	return consent
+}
+
+// SetJob stores the job or replaces the stored job with the same ID. It's useful to drive the job through its steps,
+// e.g. to simulate MFA challenges or failures.
+func (s *Server) SetJob(job basiq.Job) basiq.Job {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if job.ID == "" {
+		job.ID = s.nextID("job")
+	}
+	if job.Created.IsZero() {
+		job.Created = s.now()
+	}
+	job.Updated = s.now()
+	job.Type = "job"
+	job.Links.Self = s.link("jobs", job.ID)
+
+	for i := range s.state.jobs {
+		if s.state.jobs[i].ID == job.ID {
+			s.state.jobs[i] = job
+			return job
+		}
+	}
+	s.state.jobs = append(s.state.jobs, job)
+	return job
+}
+
+// AddInstitution stores the institution, the status defaults to operational.
+func (s *Server) AddInstitution(institution basiq.Institution) basiq.Institution {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if institution.ID == "" {
+		institution.ID = s.nextID("institution")
+	}
+	if institution.Status == "" {
+		institution.Status = basiq.InstitutionStatusOperational
+	}
+	institution.Type = "institution"
+	institution.Links.Self = s.link("institutions", institution.ID)
+	s.state.institutions = append(s.state.institutions, institution)
+	return institution
+}
+
+// SetEnrichment sets the enrichment returned for the description, other descriptions are not found.
+func (s *Server) SetEnrichment(description string, enrichment basiq.Enrichment) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.state.enrichments[description] = enrichment
+}
+
+// AddFloatAccount stores the float account, the ID is generated when it's empty.
+func (s *Server) AddFloatAccount(floatAccount basiq.FloatAccount) basiq.FloatAccount {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if floatAccount.ID == "" {
+		floatAccount.ID = s.nextID("float-account")
+	}
+	floatAccount.Type = "float-account"
+	floatAccount.Links.Self = s.link("payments", "float-accounts", floatAccount.ID)
+	s.state.floatAccounts = append(s.state.floatAccounts, floatAccount)
+	return floatAccount
+}
+
+// AddFloatAccountTransactions appends the transactions to the ledger of the float account and moves its available
+// balance by their amounts, IDs are generated when they are empty. It returns nil when there is no such account.
+func (s *Server) AddFloatAccountTransactions(floatAccountID string, transactions ...basiq.FloatAccountTransaction) []basiq.FloatAccountTransaction {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	var floatAccount *basiq.FloatAccount
+	for i := range s.state.floatAccounts {
+		if s.state.floatAccounts[i].ID == floatAccountID {
+			floatAccount = &s.state.floatAccounts[i]
+		}
+	}
+	if floatAccount == nil {
+		return nil
+	}
+
+	stored := make([]basiq.FloatAccountTransaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		if transaction.ID == "" {
+			transaction.ID = s.nextID("float-transaction")
+		}
+		if transaction.Created.IsZero() {
+			transaction.Created = s.now()
+		}
+		if transaction.Currency == "" {
+			transaction.Currency = "AUD"
+		}
+		transaction.Type = "float-account-transaction"
+		transaction.FloatAccountID = floatAccountID
+		transaction.Links.Self = s.link("payments", "float-accounts", floatAccountID, "transactions", transaction.ID)
+		floatAccount.AvailableBalance += transaction.SignedAmount()
+		stored = append(stored, transaction)
+	}
+	s.state.floatTransactions[floatAccountID] = append(s.state.floatTransactions[floatAccountID], stored...)
+	return stored
+}
+
+// SetPaymentStatus changes the status and the reason of the pay request or the payout with the given ID and records
+// the matching event. It returns false when there is no such payment.
+func (s *Server) SetPaymentStatus(paymentID string, status basiq.PaymentStatus, reason basiq.PaymentReason) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	for i := range s.state.payRequests {
+		if p := &s.state.payRequests[i]; p.ID == paymentID {
+			p.Status, p.Reason, p.Updated = status, reason, s.now()
+			s.addEvent(basiq.EventEntityPayRequest, "updated", "", "/payments/payrequests/"+p.ID, *p)
+			return true
+		}
+	}
+	for i := range s.state.payouts {
+		if p := &s.state.payouts[i]; p.ID == paymentID {
+			p.Status, p.Reason, p.Updated = status, reason, s.now()
+			s.addEvent(basiq.EventEntityPayout, "updated", "", "/payments/payouts/"+p.ID, *p)
+			return true
+		}
+	}
+	return false
+}
+
+// AddEvent records the event of the entity, the payload is encoded as the event data.
+func (s *Server) AddEvent(entity basiq.EventEntity, eventType, userID, dataRef string, payload interface{}) basiq.Event {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.addEvent(entity, eventType, userID, dataRef, payload)
+}
+
+// Events returns all recorded events, including the ones recorded by the server on changes of the resources.
+func (s *Server) Events() []basiq.Event {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return append([]basiq.Event(nil), s.state.events...)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (s *Server) addUser(user basiq.User) basiq.User {
+	if user.ID == "" {
+		user.ID = s.nextID("user")
+	}
+	user.Type = "user"
+	user.Accounts = basiq.ResourceRefList{Type: "list", Data: []basiq.ResourceRef{}}
+	user.Connections = basiq.ResourceRefList{Type: "list", Data: []basiq.ResourceRef{}}
+	user.Links = basiq.UserLinks{
+		Self:         s.link("users", user.ID),
+		Accounts:     s.link("users", user.ID, "accounts"),
+		Connections:  s.link("users", user.ID, "connections"),
+		Transactions: s.link("users", user.ID, "transactions"),
+	}
+	s.state.users = append(s.state.users, user)
+	return user
+}
+
+func (s *Server) addConnection(userID string, connection basiq.Connection) basiq.Connection {
+	if connection.ID == "" {
+		connection.ID = s.nextID("connection")
+	}
+	if connection.Status == "" {
+		connection.Status = basiq.ConnectionStatusActive
+	}
+	if connection.CreatedDate.IsZero() {
+		connection.CreatedDate = s.now()
+		connection.LastUsed = connection.CreatedDate
+	}
+	connection.Type = "connection"
+	connection.Links = basiq.ConnectionLinks{
+		Self:         s.link("users", userID, "connections", connection.ID),
+		Accounts:     s.link("users", userID, "accounts"),
+		Transactions: s.link("users", userID, "transactions"),
+		User:         s.link("users", userID),
+	}
+	s.state.connections[userID] = append(s.state.connections[userID], connection)
+	s.addRef(userID, "connection", connection.ID, connection.Links.Self)
+	return connection
+}
+
+func (s *Server) addRef(userID, refType, id, link string) {
+	for i := range s.state.users {
+		user := &s.state.users[i]
+		if user.ID != userID {
+			continue
+		}
+		ref := basiq.ResourceRef{Type: refType, ID: id, Links: basiq.SelfLink{Self: link}}
+		if refType == "account" {
+			user.Accounts.Data = append(user.Accounts.Data, ref)
+			user.Accounts.Count = len(user.Accounts.Data)
+		} else {
+			user.Connections.Data = append(user.Connections.Data, ref)
+			user.Connections.Count = len(user.Connections.Data)
+		}
+	}
+}
+
+// addJob creates a successful job of the user, the steps link to the given result.
+func (s *Server) addJob(userID, resultURL string, titles ...string) basiq.Job {
+	job := basiq.Job{ID: s.nextID("job"), Type: "job", Created: s.now()}
+	job.Updated = job.Created
+	job.Links.Self = s.link("jobs", job.ID)
+	job.Links.Source = resultURL
+	for _, title := range titles {
+		job.Steps = append(job.Steps, basiq.JobStep{
+			Title:  title,
+			Status: basiq.JobStepStatusSuccess,
+			Result: basiq.JobStepResult{Type: "link", URL: resultURL},
+		})
+	}
+	s.state.jobs = append(s.state.jobs, job)
+	s.state.jobUsers[job.ID] = userID
+	return job
+}
+
+func (s *Server) addEvent(entity basiq.EventEntity, eventType, userID, dataRef string, payload interface{}) basiq.Event {
+	raw, _ := json.Marshal(payload)
+	event := basiq.Event{
+		Type:        "event",
+		ID:          s.nextID("event"),
+		Entity:      entity,
+		EventType:   eventType,
+		UserId:      userID,
+		DataRef:     dataRef,
+		Data:        basiq.EventData{Raw: raw},
+		CreatedDate: s.now(),
+	}
+	event.Links = basiq.EventLinks{Self: s.link("events", event.ID)}
+	if dataRef != "" {
+		event.Links.Data = s.URL + dataRef
+	}
+	s.state.events = append(s.state.events, event)
+	return event
+}
+
+func (s *Server) userExists(userID string) bool {
+	for _, user := range s.state.users {
+		if user.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// link returns the absolute URL of the resource on the server.
+func (s *Server) link(segments ...string) string {
+	link := s.URL
+	for _, segment := range segments {
+		link += "/" + segment
+	}
+	return link
+}
@@ -0,0 +1,192 @@
+package basiq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Checkpoint is the position of the event subscriber. It holds the creation date of the last delivered event and
+// IDs of all delivered events created at that moment, so events sharing the timestamp are not delivered twice.
+// Events without the creation date can't be ordered, they are remembered by their IDs in UndatedEventIDs.
+type Checkpoint struct {
+	CreatedDate     Timestamp `json:"createdDate"`
+	EventIDs        []string  `json:"eventIds"`
+	UndatedEventIDs []string  `json:"undatedEventIds,omitempty"`
+}
+
+// IsZero returns true when no event has been delivered yet.
+func (c Checkpoint) IsZero() bool {
+	return c.CreatedDate.IsZero() && len(c.EventIDs) == 0 && len(c.UndatedEventIDs) == 0
+}
+
+// Covers returns true when the event has been already delivered.
+func (c Checkpoint) Covers(event Event) bool {
+	switch {
+	case event.CreatedDate.IsZero():
+		return containsID(c.UndatedEventIDs, event.ID)
+	case event.CreatedDate.Before(c.CreatedDate.Time):
+		return true
+	case event.CreatedDate.Equal(c.CreatedDate.Time):
+		return containsID(c.EventIDs, event.ID)
+	default:
+		return false
+	}
+}
+
+// Advance returns the checkpoint moved behind the given event. Events without the creation date don't move
+// the checkpoint, only their IDs are added.
+func (c Checkpoint) Advance(event Event) Checkpoint {
+	switch {
+	case event.CreatedDate.IsZero():
+		c.UndatedEventIDs = appendID(c.UndatedEventIDs, event.ID)
+	case !c.CreatedDate.IsZero() && event.CreatedDate.Equal(c.CreatedDate.Time):
+		c.EventIDs = appendID(c.EventIDs, event.ID)
+	default:
+		c.CreatedDate, c.EventIDs = event.CreatedDate, []string{event.ID}
+	}
+	return c
+}
+
+// CheckpointStore persists the checkpoints of the event subscribers under their names.
+// Load returns the zero Checkpoint when there is none stored yet.
+type CheckpointStore interface {
+	Load(ctx context.Context, name string) (Checkpoint, error)
+	Save(ctx context.Context, name string, checkpoint Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoints in memory, it's useful for tests and short-lived processes.
+// MemoryCheckpointStore is thread safe struct.
+type MemoryCheckpointStore struct {
+	checkpoints map[string]Checkpoint
+	m           sync.Mutex
+}
+
+// NewMemoryCheckpointStore instantiates an empty store.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[string]Checkpoint{}}
+}
+
+// FileCheckpointStore keeps every checkpoint in a JSON file named after the subscriber in the directory, so the names
+// of the subscribers can't contain path separators.
+// FileCheckpointStore is thread safe struct.
+type FileCheckpointStore struct {
+	dir string
+	m   sync.Mutex
+}
+
+// NewFileCheckpointStore instantiates the store and creates the directory when it doesn't exist.
+func NewFileCheckpointStore(dir string) (*FileCheckpointStore, error) {
+	if dir == "" {
+		return nil, errors.New("basiq checkpoint directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileCheckpointStore{dir: dir}, nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (s *MemoryCheckpointStore) Load(_ context.Context, name string) (Checkpoint, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.checkpoints[name], nil
+}
+
+func (s *MemoryCheckpointStore) Save(_ context.Context, name string, checkpoint Checkpoint) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.checkpoints[name] = checkpoint
+	return nil
+}
+
+func (s *FileCheckpointStore) Load(_ context.Context, name string) (Checkpoint, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	path, err := s.path(name)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Checkpoint{}, nil
+	}
+	if err != nil {
+		return Checkpoint{}, err
+	}
+
+	var checkpoint Checkpoint
+	return checkpoint, json.Unmarshal(data, &checkpoint)
+}
+
+// Save writes the checkpoint into a temporary file first and renames it, so the stored checkpoint is never partial.
+func (s *FileCheckpointStore) Save(_ context.Context, name string, checkpoint Checkpoint) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	path, err := s.path(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func containsID(ids []string, id string) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// appendID returns a copy of the IDs with the ID added, so the IDs of the previous checkpoint stay untouched.
+func appendID(ids []string, id string) []string {
+	copied := make([]string, len(ids), len(ids)+1)
+	copy(copied, ids)
+	return append(copied, id)
+}
+
+// path returns the file of the checkpoint. Names containing path separators are rejected, stripping them would make
+// different names share the same file.
+func (s *FileCheckpointStore) path(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("basiq checkpoint name %q can't be used as a file name", name)
+	}
+	return filepath.Join(s.dir, name+".json"), nil
+}
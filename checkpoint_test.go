@@ -0,0 +1,77 @@
+package basiq_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+func TestCheckpointCovers(t *testing.T) {
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	event := func(id string, createdAt time.Time) basiq.Event {
+		return basiq.Event{ID: id, CreatedDate: basiq.NewTimestamp(createdAt)}
+	}
+
+	var checkpoint basiq.Checkpoint
+	for _, delivered := range []basiq.Event{
+		event("event-1", at),
+		event("event-2", at),
+		event("event-undated", time.Time{}),
+	} {
+		checkpoint = checkpoint.Advance(delivered)
+	}
+
+	tests := []struct {
+		name  string
+		event basiq.Event
+		want  bool
+	}{
+		{"earlier event", event("event-0", at.Add(-time.Second)), true},
+		{"delivered event of the same moment", event("event-2", at), true},
+		{"new event of the same moment", event("event-3", at), false},
+		{"later event", event("event-4", at.Add(time.Second)), false},
+		{"delivered undated event", event("event-undated", time.Time{}), true},
+		{"new undated event", event("event-new-undated", time.Time{}), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkpoint.Covers(tt.event); got != tt.want {
+				t.Errorf("Covers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !checkpoint.CreatedDate.Equal(at) {
+		t.Errorf("undated event moved the checkpoint to %s", checkpoint.CreatedDate)
+	}
+	if later := checkpoint.Advance(event("event-4", at.Add(time.Second))); !later.Covers(event("event-undated", time.Time{})) {
+		t.Error("advanced checkpoint forgot the delivered undated event")
+	}
+}
+
+func TestFileCheckpointStoreRejectsPathNames(t *testing.T) {
+	store, err := basiq.NewFileCheckpointStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore: %v", err)
+	}
+
+	ctx := context.Background()
+	checkpoint := basiq.Checkpoint{EventIDs: []string{"event-1"}}
+	if err = store.Save(ctx, "subscriber", checkpoint); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if loaded, err := store.Load(ctx, "subscriber"); err != nil || len(loaded.EventIDs) != 1 {
+		t.Errorf("Load returned %+v, %v", loaded, err)
+	}
+
+	for _, name := range []string{"", "a/subscriber", `b\subscriber`, "../subscriber"} {
+		if err = store.Save(ctx, name, checkpoint); err == nil {
+			t.Errorf("Save accepted the name %q", name)
+		}
+		if _, err = store.Load(ctx, name); err == nil {
+			t.Errorf("Load accepted the name %q", name)
+		}
+	}
+}
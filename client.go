@@ -0,0 +1,124 @@
+package basiq
+
+import (
+	"context"
+	"time"
+)
+
+// UsersAPI manages the users, their auth links and consents.
+type UsersAPI interface {
+	User(ctx context.Context, userID string) (User, error)
+	CreateUser(ctx context.Context, params UserParams) (User, error)
+	UpdateUser(ctx context.Context, userID string, params UserParams) (User, error)
+	DeleteUser(ctx context.Context, userID string) error
+	Users(ctx context.Context) *UserIterator
+	FindUsers(ctx context.Context, params UserParams) ([]User, error)
+	AuthLink(ctx context.Context, userID string) (AuthLink, error)
+	CreateAuthLink(ctx context.Context, userID string, params AuthLinkParams) (AuthLink, error)
+	DeleteAuthLink(ctx context.Context, userID string) error
+	UserConsents(ctx context.Context, userID string) ([]UserConsent, error)
+	UserConsent(ctx context.Context, userID string) (UserConsent, error)
+	DeleteUserConsent(ctx context.Context, userID, consentID string) error
+	ExpiringConsents(ctx context.Context, userIDs []string, window time.Duration) ([]ExpiringConsent, error)
+	CheckDataRetention(ctx context.Context, userID string) error
+	InvalidateConsent(userID string)
+}
+
+// ConnectionsAPI manages the connections of the users to the institutions and the jobs retrieving their data.
+type ConnectionsAPI interface {
+	Connection(ctx context.Context, userID, connectionID string) (Connection, error)
+	Connections(ctx context.Context, userID string) ([]Connection, error)
+	CreateConnection(ctx context.Context, userID string, credentials Credentials) (Job, error)
+	UpdateConnection(ctx context.Context, userID, connectionID string, credentials Credentials) (Job, error)
+	UploadStatement(ctx context.Context, userID string, params StatementParams) (Job, error)
+	RefreshConnection(ctx context.Context, userID, connectionID string) (Connection, error)
+	RefreshConnections(ctx context.Context, userID string) ([]Connection, error)
+	DeleteConnection(ctx context.Context, userID, connectionID string) error
+	Connector(ctx context.Context, connectorID, method string) (Connector, error)
+	Connectors(ctx context.Context) ([]Connector, error)
+	Institution(ctx context.Context, institutionID string) (Institution, error)
+	Institutions(ctx context.Context, params InstitutionParams) ([]Institution, error)
+	Job(ctx context.Context, jobID string) (Job, error)
+	UserJobs(ctx context.Context, userID string) ([]UserJob, error)
+	WaitForJob(ctx context.Context, jobID string, opts JobWaitOptions) (Job, error)
+	CreateMFAResponse(ctx context.Context, jobID string, params MFAParams) (MFA, error)
+}
+
+// DataAPI reads the accounts, transactions and identities retrieved from the institutions and enriches
+// the transactions from other sources.
+type DataAPI interface {
+	Account(ctx context.Context, userID, accountID string) (Account, error)
+	Accounts(ctx context.Context, userID string) ([]Account, error)
+	Transaction(ctx context.Context, userID, transactionID string) (Transaction, error)
+	Transactions(ctx context.Context, userID string) ([]Transaction, error)
+	Identity(ctx context.Context, userID, identityID string) (Identity, error)
+	Identities(ctx context.Context, userID string) ([]Identity, error)
+	Enrich(ctx context.Context, params EnrichParams) (Enrichment, error)
+	EnrichBatch(ctx context.Context, params []EnrichParams, concurrency int) []EnrichResult
+}
+
+// InsightsAPI creates and reads the affordability, income and expense snapshots and the verification reports.
+type InsightsAPI interface {
+	Affordability(ctx context.Context, userID, snapshotID string) (Affordability, error)
+	CreateAffordability(ctx context.Context, userID string, params AffordabilityParams) (Affordability, error)
+	AffordabilitySummaries(ctx context.Context, userID string) ([]AffordabilitySummary, error)
+	AffordabilityTransactions(ctx context.Context, userID, snapshotID string) ([]AffordabilityTransaction, error)
+	IncomeSummary(ctx context.Context, userID, snapshotID string) (IncomeSummary, error)
+	CreateIncomeSummary(ctx context.Context, userID string, params IncomeSummaryParams) (IncomeSummary, error)
+	IncomeSummaries(ctx context.Context, userID string) ([]IncomeSummaryItem, error)
+	DeleteIncomeSummary(ctx context.Context, userID, snapshotID string) error
+	ExpenseSummary(ctx context.Context, userID, snapshotID string) (ExpenseSummary, error)
+	CreateExpenseSummary(ctx context.Context, userID string, params ExpenseSummaryParams) (ExpenseSummary, error)
+	ExpenseSummaries(ctx context.Context, userID string) ([]ExpenseSummaryItem, error)
+	DeleteExpenseSummary(ctx context.Context, userID, snapshotID string) error
+	Report(ctx context.Context, reportID string) (Report, error)
+	Reports(ctx context.Context) ([]Report, error)
+	CreateReport(ctx context.Context, params ReportParams) (Job, error)
+	WaitForReport(ctx context.Context, jobID string, opts JobWaitOptions) (Report, error)
+}
+
+// PaymentsAPI manages the pay requests, payouts and float accounts.
+type PaymentsAPI interface {
+	PayRequest(ctx context.Context, payRequestID string) (PayRequest, error)
+	PayRequests(ctx context.Context) ([]PayRequest, error)
+	CreatePayRequest(ctx context.Context, params PayRequestParams) ([]PayRequestJob, error)
+	Payout(ctx context.Context, payoutID string) (Payout, error)
+	Payouts(ctx context.Context) ([]Payout, error)
+	CreatePayout(ctx context.Context, params PayoutParams) ([]PayoutJob, error)
+	FloatAccount(ctx context.Context, floatAccountID string) (FloatAccount, error)
+	FloatAccounts(ctx context.Context) ([]FloatAccount, error)
+	FloatAccountTransactions(ctx context.Context, floatAccountID string) ([]FloatAccountTransaction, error)
+	WaitForPayment(ctx context.Context, jobID string, opts PaymentWaitOptions) (Payment, error)
+	WaitForPayments(ctx context.Context, jobIDs []string, opts PaymentWaitOptions) []PaymentResult
+}
+
+// EventsAPI reads the events and manages the webhooks delivering them.
+type EventsAPI interface {
+	Events(ctx context.Context) ([]Event, error)
+	FilteredEvents(ctx context.Context, params EventParams) ([]Event, error)
+	ResolveEvent(ctx context.Context, event Event) (EventData, error)
+	Webhook(ctx context.Context, webhookID string) (Webhook, error)
+	Webhooks(ctx context.Context) ([]Webhook, error)
+	CreateWebhook(ctx context.Context, params WebhookParams) (Webhook, error)
+	UpdateWebhook(ctx context.Context, webhookID string, params WebhookParams) (Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	WebhookSecret(ctx context.Context, webhookID string) (WebhookSecret, error)
+	SendTestWebhook(ctx context.Context, webhookID string, params WebhookTestParams) error
+}
+
+// Client is the whole Basiq API, it's implemented by the API and the MockClient. Depend on the domain interfaces
+// where the whole client is not needed.
+type Client interface {
+	UsersAPI
+	ConnectionsAPI
+	DataAPI
+	InsightsAPI
+	PaymentsAPI
+	EventsAPI
+	Authenticate(ctx context.Context) error
+}
+
+var (
+	_ Client = (*API)(nil)
+	_ Client = (*MockClient)(nil)
+)
@@ -0,0 +1,753 @@
+package basiq
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MockCall is a single call recorded by the MockClient, Args hold the arguments without the context.
+type MockCall struct {
+	Method string
+	Args   []interface{}
+}
+
+// MockClient is a stub Client for tests. Every method records the call and delegates to the matching func field,
+// zero values are returned when the field is not set. Embed it to decorate only some of the methods.
+// MockClient is thread safe struct.
+type MockClient struct {
+	UserFunc                      func(ctx context.Context, userID string) (User, error)
+	CreateUserFunc                func(ctx context.Context, params UserParams) (User, error)
+	UpdateUserFunc                func(ctx context.Context, userID string, params UserParams) (User, error)
+	DeleteUserFunc                func(ctx context.Context, userID string) error
+	UsersFunc                     func(ctx context.Context) *UserIterator
+	FindUsersFunc                 func(ctx context.Context, params UserParams) ([]User, error)
+	AuthLinkFunc                  func(ctx context.Context, userID string) (AuthLink, error)
+	CreateAuthLinkFunc            func(ctx context.Context, userID string, params AuthLinkParams) (AuthLink, error)
+	DeleteAuthLinkFunc            func(ctx context.Context, userID string) error
+	UserConsentsFunc              func(ctx context.Context, userID string) ([]UserConsent, error)
+	UserConsentFunc               func(ctx context.Context, userID string) (UserConsent, error)
+	DeleteUserConsentFunc         func(ctx context.Context, userID, consentID string) error
+	ExpiringConsentsFunc          func(ctx context.Context, userIDs []string, window time.Duration) ([]ExpiringConsent, error)
+	CheckDataRetentionFunc        func(ctx context.Context, userID string) error
+	InvalidateConsentFunc         func(userID string)
+	ConnectionFunc                func(ctx context.Context, userID, connectionID string) (Connection, error)
+	ConnectionsFunc               func(ctx context.Context, userID string) ([]Connection, error)
+	CreateConnectionFunc          func(ctx context.Context, userID string, credentials Credentials) (Job, error)
+	UpdateConnectionFunc          func(ctx context.Context, userID, connectionID string, credentials Credentials) (Job, error)
+	UploadStatementFunc           func(ctx context.Context, userID string, params StatementParams) (Job, error)
+	RefreshConnectionFunc         func(ctx context.Context, userID, connectionID string) (Connection, error)
+	RefreshConnectionsFunc        func(ctx context.Context, userID string) ([]Connection, error)
+	DeleteConnectionFunc          func(ctx context.Context, userID, connectionID string) error
+	ConnectorFunc                 func(ctx context.Context, connectorID, method string) (Connector, error)
+	ConnectorsFunc                func(ctx context.Context) ([]Connector, error)
+	InstitutionFunc               func(ctx context.Context, institutionID string) (Institution, error)
+	InstitutionsFunc              func(ctx context.Context, params InstitutionParams) ([]Institution, error)
+	JobFunc                       func(ctx context.Context, jobID string) (Job, error)
+	UserJobsFunc                  func(ctx context.Context, userID string) ([]UserJob, error)
+	WaitForJobFunc                func(ctx context.Context, jobID string, opts JobWaitOptions) (Job, error)
+	CreateMFAResponseFunc         func(ctx context.Context, jobID string, params MFAParams) (MFA, error)
+	AccountFunc                   func(ctx context.Context, userID, accountID string) (Account, error)
+	AccountsFunc                  func(ctx context.Context, userID string) ([]Account, error)
+	TransactionFunc               func(ctx context.Context, userID, transactionID string) (Transaction, error)
+	TransactionsFunc              func(ctx context.Context, userID string) ([]Transaction, error)
+	IdentityFunc                  func(ctx context.Context, userID, identityID string) (Identity, error)
+	IdentitiesFunc                func(ctx context.Context, userID string) ([]Identity, error)
+	EnrichFunc                    func(ctx context.Context, params EnrichParams) (Enrichment, error)
+	EnrichBatchFunc               func(ctx context.Context, params []EnrichParams, concurrency int) []EnrichResult
+	AffordabilityFunc             func(ctx context.Context, userID, snapshotID string) (Affordability, error)
+	CreateAffordabilityFunc       func(ctx context.Context, userID string, params AffordabilityParams) (Affordability, error)
+	AffordabilitySummariesFunc    func(ctx context.Context, userID string) ([]AffordabilitySummary, error)
+	AffordabilityTransactionsFunc func(ctx context.Context, userID, snapshotID string) ([]AffordabilityTransaction, error)
+	IncomeSummaryFunc             func(ctx context.Context, userID, snapshotID string) (IncomeSummary, error)
+	CreateIncomeSummaryFunc       func(ctx context.Context, userID string, params IncomeSummaryParams) (IncomeSummary, error)
+	IncomeSummariesFunc           func(ctx context.Context, userID string) ([]IncomeSummaryItem, error)
+	DeleteIncomeSummaryFunc       func(ctx context.Context, userID, snapshotID string) error
+	ExpenseSummaryFunc            func(ctx context.Context, userID, snapshotID string) (ExpenseSummary, error)
+	CreateExpenseSummaryFunc      func(ctx context.Context, userID string, params ExpenseSummaryParams) (ExpenseSummary, error)
+	ExpenseSummariesFunc          func(ctx context.Context, userID string) ([]ExpenseSummaryItem, error)
+	DeleteExpenseSummaryFunc      func(ctx context.Context, userID, snapshotID string) error
+	ReportFunc                    func(ctx context.Context, reportID string) (Report, error)
+	ReportsFunc                   func(ctx context.Context) ([]Report, error)
+	CreateReportFunc              func(ctx context.Context, params ReportParams) (Job, error)
+	WaitForReportFunc             func(ctx context.Context, jobID string, opts JobWaitOptions) (Report, error)
+	PayRequestFunc                func(ctx context.Context, payRequestID string) (PayRequest, error)
+	PayRequestsFunc               func(ctx context.Context) ([]PayRequest, error)
+	CreatePayRequestFunc          func(ctx context.Context, params PayRequestParams) ([]PayRequestJob, error)
+	PayoutFunc                    func(ctx context.Context, payoutID string) (Payout, error)
+	PayoutsFunc                   func(ctx context.Context) ([]Payout, error)
+	CreatePayoutFunc              func(ctx context.Context, params PayoutParams) ([]PayoutJob, error)
+	FloatAccountFunc              func(ctx context.Context, floatAccountID string) (FloatAccount, error)
+	FloatAccountsFunc             func(ctx context.Context) ([]FloatAccount, error)
+	FloatAccountTransactionsFunc  func(ctx context.Context, floatAccountID string) ([]FloatAccountTransaction, error)
+	WaitForPaymentFunc            func(ctx context.Context, jobID string, opts PaymentWaitOptions) (Payment, error)
+	WaitForPaymentsFunc           func(ctx context.Context, jobIDs []string, opts PaymentWaitOptions) []PaymentResult
+	EventsFunc                    func(ctx context.Context) ([]Event, error)
+	FilteredEventsFunc            func(ctx context.Context, params EventParams) ([]Event, error)
+	ResolveEventFunc              func(ctx context.Context, event Event) (EventData, error)
+	WebhookFunc                   func(ctx context.Context, webhookID string) (Webhook, error)
+	WebhooksFunc                  func(ctx context.Context) ([]Webhook, error)
+	CreateWebhookFunc             func(ctx context.Context, params WebhookParams) (Webhook, error)
+	UpdateWebhookFunc             func(ctx context.Context, webhookID string, params WebhookParams) (Webhook, error)
+	DeleteWebhookFunc             func(ctx context.Context, webhookID string) error
+	WebhookSecretFunc             func(ctx context.Context, webhookID string) (WebhookSecret, error)
+	SendTestWebhookFunc           func(ctx context.Context, webhookID string, params WebhookTestParams) error
+	AuthenticateFunc              func(ctx context.Context) error
+
+	calls []MockCall
+	m     sync.Mutex
+}
+
+// Calls returns all recorded calls in the order they happened.
+func (m *MockClient) Calls() []MockCall {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	return append([]MockCall(nil), m.calls...)
+}
+
+// CallsTo returns the recorded calls of the method.
+func (m *MockClient) CallsTo(method string) []MockCall {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	var calls []MockCall
+	for _, call := range m.calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Reset drops all recorded calls.
+func (m *MockClient) Reset() {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.calls = nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (m *MockClient) User(ctx context.Context, userID string) (User, error) {
+	m.record("User", userID)
+	if m.UserFunc != nil {
+		return m.UserFunc(ctx, userID)
+	}
+	return User{}, nil
+}
+
+func (m *MockClient) CreateUser(ctx context.Context, params UserParams) (User, error) {
+	m.record("CreateUser", params)
+	if m.CreateUserFunc != nil {
+		return m.CreateUserFunc(ctx, params)
+	}
+	return User{}, nil
+}
+
+func (m *MockClient) UpdateUser(ctx context.Context, userID string, params UserParams) (User, error) {
+	m.record("UpdateUser", userID, params)
+	if m.UpdateUserFunc != nil {
+		return m.UpdateUserFunc(ctx, userID, params)
+	}
+	return User{}, nil
+}
+
+func (m *MockClient) DeleteUser(ctx context.Context, userID string) error {
+	m.record("DeleteUser", userID)
+	if m.DeleteUserFunc != nil {
+		return m.DeleteUserFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockClient) Users(ctx context.Context) *UserIterator {
+	m.record("Users")
+	if m.UsersFunc != nil {
+		return m.UsersFunc(ctx)
+	}
+	return nil
+}
+
+func (m *MockClient) FindUsers(ctx context.Context, params UserParams) ([]User, error) {
+	m.record("FindUsers", params)
+	if m.FindUsersFunc != nil {
+		return m.FindUsersFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) AuthLink(ctx context.Context, userID string) (AuthLink, error) {
+	m.record("AuthLink", userID)
+	if m.AuthLinkFunc != nil {
+		return m.AuthLinkFunc(ctx, userID)
+	}
+	return AuthLink{}, nil
+}
+
+func (m *MockClient) CreateAuthLink(ctx context.Context, userID string, params AuthLinkParams) (AuthLink, error) {
+	m.record("CreateAuthLink", userID, params)
+	if m.CreateAuthLinkFunc != nil {
+		return m.CreateAuthLinkFunc(ctx, userID, params)
+	}
+	return AuthLink{}, nil
+}
+
+func (m *MockClient) DeleteAuthLink(ctx context.Context, userID string) error {
+	m.record("DeleteAuthLink", userID)
+	if m.DeleteAuthLinkFunc != nil {
+		return m.DeleteAuthLinkFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockClient) UserConsents(ctx context.Context, userID string) ([]UserConsent, error) {
+	m.record("UserConsents", userID)
+	if m.UserConsentsFunc != nil {
+		return m.UserConsentsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) UserConsent(ctx context.Context, userID string) (UserConsent, error) {
+	m.record("UserConsent", userID)
+	if m.UserConsentFunc != nil {
+		return m.UserConsentFunc(ctx, userID)
+	}
+	return UserConsent{}, nil
+}
+
+func (m *MockClient) DeleteUserConsent(ctx context.Context, userID, consentID string) error {
+	m.record("DeleteUserConsent", userID, consentID)
+	if m.DeleteUserConsentFunc != nil {
+		return m.DeleteUserConsentFunc(ctx, userID, consentID)
+	}
+	return nil
+}
+
+func (m *MockClient) ExpiringConsents(ctx context.Context, userIDs []string, window time.Duration) ([]ExpiringConsent, error) {
+	m.record("ExpiringConsents", userIDs, window)
+	if m.ExpiringConsentsFunc != nil {
+		return m.ExpiringConsentsFunc(ctx, userIDs, window)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CheckDataRetention(ctx context.Context, userID string) error {
+	m.record("CheckDataRetention", userID)
+	if m.CheckDataRetentionFunc != nil {
+		return m.CheckDataRetentionFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockClient) InvalidateConsent(userID string) {
+	m.record("InvalidateConsent", userID)
+	if m.InvalidateConsentFunc != nil {
+		m.InvalidateConsentFunc(userID)
+	}
+}
+
+func (m *MockClient) Connection(ctx context.Context, userID, connectionID string) (Connection, error) {
+	m.record("Connection", userID, connectionID)
+	if m.ConnectionFunc != nil {
+		return m.ConnectionFunc(ctx, userID, connectionID)
+	}
+	return Connection{}, nil
+}
+
+func (m *MockClient) Connections(ctx context.Context, userID string) ([]Connection, error) {
+	m.record("Connections", userID)
+	if m.ConnectionsFunc != nil {
+		return m.ConnectionsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CreateConnection(ctx context.Context, userID string, credentials Credentials) (Job, error) {
+	m.record("CreateConnection", userID, credentials)
+	if m.CreateConnectionFunc != nil {
+		return m.CreateConnectionFunc(ctx, userID, credentials)
+	}
+	return Job{}, nil
+}
+
+func (m *MockClient) UpdateConnection(ctx context.Context, userID, connectionID string, credentials Credentials) (Job, error) {
+	m.record("UpdateConnection", userID, connectionID, credentials)
+	if m.UpdateConnectionFunc != nil {
+		return m.UpdateConnectionFunc(ctx, userID, connectionID, credentials)
+	}
+	return Job{}, nil
+}
+
+func (m *MockClient) UploadStatement(ctx context.Context, userID string, params StatementParams) (Job, error) {
+	m.record("UploadStatement", userID, params)
+	if m.UploadStatementFunc != nil {
+		return m.UploadStatementFunc(ctx, userID, params)
+	}
+	return Job{}, nil
+}
+
+func (m *MockClient) RefreshConnection(ctx context.Context, userID, connectionID string) (Connection, error) {
+	m.record("RefreshConnection", userID, connectionID)
+	if m.RefreshConnectionFunc != nil {
+		return m.RefreshConnectionFunc(ctx, userID, connectionID)
+	}
+	return Connection{}, nil
+}
+
+func (m *MockClient) RefreshConnections(ctx context.Context, userID string) ([]Connection, error) {
+	m.record("RefreshConnections", userID)
+	if m.RefreshConnectionsFunc != nil {
+		return m.RefreshConnectionsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeleteConnection(ctx context.Context, userID, connectionID string) error {
+	m.record("DeleteConnection", userID, connectionID)
+	if m.DeleteConnectionFunc != nil {
+		return m.DeleteConnectionFunc(ctx, userID, connectionID)
+	}
+	return nil
+}
+
+func (m *MockClient) Connector(ctx context.Context, connectorID, method string) (Connector, error) {
+	m.record("Connector", connectorID, method)
+	if m.ConnectorFunc != nil {
+		return m.ConnectorFunc(ctx, connectorID, method)
+	}
+	return Connector{}, nil
+}
+
+func (m *MockClient) Connectors(ctx context.Context) ([]Connector, error) {
+	m.record("Connectors")
+	if m.ConnectorsFunc != nil {
+		return m.ConnectorsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) Institution(ctx context.Context, institutionID string) (Institution, error) {
+	m.record("Institution", institutionID)
+	if m.InstitutionFunc != nil {
+		return m.InstitutionFunc(ctx, institutionID)
+	}
+	return Institution{}, nil
+}
+
+func (m *MockClient) Institutions(ctx context.Context, params InstitutionParams) ([]Institution, error) {
+	m.record("Institutions", params)
+	if m.InstitutionsFunc != nil {
+		return m.InstitutionsFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) Job(ctx context.Context, jobID string) (Job, error) {
+	m.record("Job", jobID)
+	if m.JobFunc != nil {
+		return m.JobFunc(ctx, jobID)
+	}
+	return Job{}, nil
+}
+
+func (m *MockClient) UserJobs(ctx context.Context, userID string) ([]UserJob, error) {
+	m.record("UserJobs", userID)
+	if m.UserJobsFunc != nil {
+		return m.UserJobsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) WaitForJob(ctx context.Context, jobID string, opts JobWaitOptions) (Job, error) {
+	m.record("WaitForJob", jobID, opts)
+	if m.WaitForJobFunc != nil {
+		return m.WaitForJobFunc(ctx, jobID, opts)
+	}
+	return Job{}, nil
+}
+
+func (m *MockClient) CreateMFAResponse(ctx context.Context, jobID string, params MFAParams) (MFA, error) {
+	m.record("CreateMFAResponse", jobID, params)
+	if m.CreateMFAResponseFunc != nil {
+		return m.CreateMFAResponseFunc(ctx, jobID, params)
+	}
+	return MFA{}, nil
+}
+
+func (m *MockClient) Account(ctx context.Context, userID, accountID string) (Account, error) {
+	m.record("Account", userID, accountID)
+	if m.AccountFunc != nil {
+		return m.AccountFunc(ctx, userID, accountID)
+	}
+	return Account{}, nil
+}
+
+func (m *MockClient) Accounts(ctx context.Context, userID string) ([]Account, error) {
+	m.record("Accounts", userID)
+	if m.AccountsFunc != nil {
+		return m.AccountsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) Transaction(ctx context.Context, userID, transactionID string) (Transaction, error) {
+	m.record("Transaction", userID, transactionID)
+	if m.TransactionFunc != nil {
+		return m.TransactionFunc(ctx, userID, transactionID)
+	}
+	return Transaction{}, nil
+}
+
+func (m *MockClient) Transactions(ctx context.Context, userID string) ([]Transaction, error) {
+	m.record("Transactions", userID)
+	if m.TransactionsFunc != nil {
+		return m.TransactionsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) Identity(ctx context.Context, userID, identityID string) (Identity, error) {
+	m.record("Identity", userID, identityID)
+	if m.IdentityFunc != nil {
+		return m.IdentityFunc(ctx, userID, identityID)
+	}
+	return Identity{}, nil
+}
+
+func (m *MockClient) Identities(ctx context.Context, userID string) ([]Identity, error) {
+	m.record("Identities", userID)
+	if m.IdentitiesFunc != nil {
+		return m.IdentitiesFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) Enrich(ctx context.Context, params EnrichParams) (Enrichment, error) {
+	m.record("Enrich", params)
+	if m.EnrichFunc != nil {
+		return m.EnrichFunc(ctx, params)
+	}
+	return Enrichment{}, nil
+}
+
+func (m *MockClient) EnrichBatch(ctx context.Context, params []EnrichParams, concurrency int) []EnrichResult {
+	m.record("EnrichBatch", params, concurrency)
+	if m.EnrichBatchFunc != nil {
+		return m.EnrichBatchFunc(ctx, params, concurrency)
+	}
+	return nil
+}
+
+func (m *MockClient) Affordability(ctx context.Context, userID, snapshotID string) (Affordability, error) {
+	m.record("Affordability", userID, snapshotID)
+	if m.AffordabilityFunc != nil {
+		return m.AffordabilityFunc(ctx, userID, snapshotID)
+	}
+	return Affordability{}, nil
+}
+
+func (m *MockClient) CreateAffordability(ctx context.Context, userID string, params AffordabilityParams) (Affordability, error) {
+	m.record("CreateAffordability", userID, params)
+	if m.CreateAffordabilityFunc != nil {
+		return m.CreateAffordabilityFunc(ctx, userID, params)
+	}
+	return Affordability{}, nil
+}
+
+func (m *MockClient) AffordabilitySummaries(ctx context.Context, userID string) ([]AffordabilitySummary, error) {
+	m.record("AffordabilitySummaries", userID)
+	if m.AffordabilitySummariesFunc != nil {
+		return m.AffordabilitySummariesFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) AffordabilityTransactions(ctx context.Context, userID, snapshotID string) ([]AffordabilityTransaction, error) {
+	m.record("AffordabilityTransactions", userID, snapshotID)
+	if m.AffordabilityTransactionsFunc != nil {
+		return m.AffordabilityTransactionsFunc(ctx, userID, snapshotID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) IncomeSummary(ctx context.Context, userID, snapshotID string) (IncomeSummary, error) {
+	m.record("IncomeSummary", userID, snapshotID)
+	if m.IncomeSummaryFunc != nil {
+		return m.IncomeSummaryFunc(ctx, userID, snapshotID)
+	}
+	return IncomeSummary{}, nil
+}
+
+func (m *MockClient) CreateIncomeSummary(ctx context.Context, userID string, params IncomeSummaryParams) (IncomeSummary, error) {
+	m.record("CreateIncomeSummary", userID, params)
+	if m.CreateIncomeSummaryFunc != nil {
+		return m.CreateIncomeSummaryFunc(ctx, userID, params)
+	}
+	return IncomeSummary{}, nil
+}
+
+func (m *MockClient) IncomeSummaries(ctx context.Context, userID string) ([]IncomeSummaryItem, error) {
+	m.record("IncomeSummaries", userID)
+	if m.IncomeSummariesFunc != nil {
+		return m.IncomeSummariesFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeleteIncomeSummary(ctx context.Context, userID, snapshotID string) error {
+	m.record("DeleteIncomeSummary", userID, snapshotID)
+	if m.DeleteIncomeSummaryFunc != nil {
+		return m.DeleteIncomeSummaryFunc(ctx, userID, snapshotID)
+	}
+	return nil
+}
+
+func (m *MockClient) ExpenseSummary(ctx context.Context, userID, snapshotID string) (ExpenseSummary, error) {
+	m.record("ExpenseSummary", userID, snapshotID)
+	if m.ExpenseSummaryFunc != nil {
+		return m.ExpenseSummaryFunc(ctx, userID, snapshotID)
+	}
+	return ExpenseSummary{}, nil
+}
+
+func (m *MockClient) CreateExpenseSummary(ctx context.Context, userID string, params ExpenseSummaryParams) (ExpenseSummary, error) {
+	m.record("CreateExpenseSummary", userID, params)
+	if m.CreateExpenseSummaryFunc != nil {
+		return m.CreateExpenseSummaryFunc(ctx, userID, params)
+	}
+	return ExpenseSummary{}, nil
+}
+
+func (m *MockClient) ExpenseSummaries(ctx context.Context, userID string) ([]ExpenseSummaryItem, error) {
+	m.record("ExpenseSummaries", userID)
+	if m.ExpenseSummariesFunc != nil {
+		return m.ExpenseSummariesFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) DeleteExpenseSummary(ctx context.Context, userID, snapshotID string) error {
+	m.record("DeleteExpenseSummary", userID, snapshotID)
+	if m.DeleteExpenseSummaryFunc != nil {
+		return m.DeleteExpenseSummaryFunc(ctx, userID, snapshotID)
+	}
+	return nil
+}
+
+func (m *MockClient) Report(ctx context.Context, reportID string) (Report, error) {
+	m.record("Report", reportID)
+	if m.ReportFunc != nil {
+		return m.ReportFunc(ctx, reportID)
+	}
+	return Report{}, nil
+}
+
+func (m *MockClient) Reports(ctx context.Context) ([]Report, error) {
+	m.record("Reports")
+	if m.ReportsFunc != nil {
+		return m.ReportsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CreateReport(ctx context.Context, params ReportParams) (Job, error) {
+	m.record("CreateReport", params)
+	if m.CreateReportFunc != nil {
+		return m.CreateReportFunc(ctx, params)
+	}
+	return Job{}, nil
+}
+
+func (m *MockClient) WaitForReport(ctx context.Context, jobID string, opts JobWaitOptions) (Report, error) {
+	m.record("WaitForReport", jobID, opts)
+	if m.WaitForReportFunc != nil {
+		return m.WaitForReportFunc(ctx, jobID, opts)
+	}
+	return Report{}, nil
+}
+
+func (m *MockClient) PayRequest(ctx context.Context, payRequestID string) (PayRequest, error) {
+	m.record("PayRequest", payRequestID)
+	if m.PayRequestFunc != nil {
+		return m.PayRequestFunc(ctx, payRequestID)
+	}
+	return PayRequest{}, nil
+}
+
+func (m *MockClient) PayRequests(ctx context.Context) ([]PayRequest, error) {
+	m.record("PayRequests")
+	if m.PayRequestsFunc != nil {
+		return m.PayRequestsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CreatePayRequest(ctx context.Context, params PayRequestParams) ([]PayRequestJob, error) {
+	m.record("CreatePayRequest", params)
+	if m.CreatePayRequestFunc != nil {
+		return m.CreatePayRequestFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) Payout(ctx context.Context, payoutID string) (Payout, error) {
+	m.record("Payout", payoutID)
+	if m.PayoutFunc != nil {
+		return m.PayoutFunc(ctx, payoutID)
+	}
+	return Payout{}, nil
+}
+
+func (m *MockClient) Payouts(ctx context.Context) ([]Payout, error) {
+	m.record("Payouts")
+	if m.PayoutsFunc != nil {
+		return m.PayoutsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CreatePayout(ctx context.Context, params PayoutParams) ([]PayoutJob, error) {
+	m.record("CreatePayout", params)
+	if m.CreatePayoutFunc != nil {
+		return m.CreatePayoutFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) FloatAccount(ctx context.Context, floatAccountID string) (FloatAccount, error) {
+	m.record("FloatAccount", floatAccountID)
+	if m.FloatAccountFunc != nil {
+		return m.FloatAccountFunc(ctx, floatAccountID)
+	}
+	return FloatAccount{}, nil
+}
+
+func (m *MockClient) FloatAccounts(ctx context.Context) ([]FloatAccount, error) {
+	m.record("FloatAccounts")
+	if m.FloatAccountsFunc != nil {
+		return m.FloatAccountsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) FloatAccountTransactions(ctx context.Context, floatAccountID string) ([]FloatAccountTransaction, error) {
+	m.record("FloatAccountTransactions", floatAccountID)
+	if m.FloatAccountTransactionsFunc != nil {
+		return m.FloatAccountTransactionsFunc(ctx, floatAccountID)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) WaitForPayment(ctx context.Context, jobID string, opts PaymentWaitOptions) (Payment, error) {
+	m.record("WaitForPayment", jobID, opts)
+	if m.WaitForPaymentFunc != nil {
+		return m.WaitForPaymentFunc(ctx, jobID, opts)
+	}
+	return Payment{}, nil
+}
+
+func (m *MockClient) WaitForPayments(ctx context.Context, jobIDs []string, opts PaymentWaitOptions) []PaymentResult {
+	m.record("WaitForPayments", jobIDs, opts)
+	if m.WaitForPaymentsFunc != nil {
+		return m.WaitForPaymentsFunc(ctx, jobIDs, opts)
+	}
+	return nil
+}
+
+func (m *MockClient) Events(ctx context.Context) ([]Event, error) {
+	m.record("Events")
+	if m.EventsFunc != nil {
+		return m.EventsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) FilteredEvents(ctx context.Context, params EventParams) ([]Event, error) {
+	m.record("FilteredEvents", params)
+	if m.FilteredEventsFunc != nil {
+		return m.FilteredEventsFunc(ctx, params)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) ResolveEvent(ctx context.Context, event Event) (EventData, error) {
+	m.record("ResolveEvent", event)
+	if m.ResolveEventFunc != nil {
+		return m.ResolveEventFunc(ctx, event)
+	}
+	return EventData{}, nil
+}
+
+func (m *MockClient) Webhook(ctx context.Context, webhookID string) (Webhook, error) {
+	m.record("Webhook", webhookID)
+	if m.WebhookFunc != nil {
+		return m.WebhookFunc(ctx, webhookID)
+	}
+	return Webhook{}, nil
+}
+
+func (m *MockClient) Webhooks(ctx context.Context) ([]Webhook, error) {
+	m.record("Webhooks")
+	if m.WebhooksFunc != nil {
+		return m.WebhooksFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockClient) CreateWebhook(ctx context.Context, params WebhookParams) (Webhook, error) {
+	m.record("CreateWebhook", params)
+	if m.CreateWebhookFunc != nil {
+		return m.CreateWebhookFunc(ctx, params)
+	}
+	return Webhook{}, nil
+}
+
+func (m *MockClient) UpdateWebhook(ctx context.Context, webhookID string, params WebhookParams) (Webhook, error) {
+	m.record("UpdateWebhook", webhookID, params)
+	if m.UpdateWebhookFunc != nil {
+		return m.UpdateWebhookFunc(ctx, webhookID, params)
+	}
+	return Webhook{}, nil
+}
+
+func (m *MockClient) DeleteWebhook(ctx context.Context, webhookID string) error {
+	m.record("DeleteWebhook", webhookID)
+	if m.DeleteWebhookFunc != nil {
+		return m.DeleteWebhookFunc(ctx, webhookID)
+	}
+	return nil
+}
+
+func (m *MockClient) WebhookSecret(ctx context.Context, webhookID string) (WebhookSecret, error) {
+	m.record("WebhookSecret", webhookID)
+	if m.WebhookSecretFunc != nil {
+		return m.WebhookSecretFunc(ctx, webhookID)
+	}
+	return WebhookSecret{}, nil
+}
+
+func (m *MockClient) SendTestWebhook(ctx context.Context, webhookID string, params WebhookTestParams) error {
+	m.record("SendTestWebhook", webhookID, params)
+	if m.SendTestWebhookFunc != nil {
+		return m.SendTestWebhookFunc(ctx, webhookID, params)
+	}
+	return nil
+}
+
+func (m *MockClient) Authenticate(ctx context.Context) error {
+	m.record("Authenticate")
+	if m.AuthenticateFunc != nil {
+		return m.AuthenticateFunc(ctx)
+	}
+	return nil
+}
+
+func (m *MockClient) record(method string, args ...interface{}) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	m.calls = append(m.calls, MockCall{Method: method, Args: args})
+}
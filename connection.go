@@ -1,88 +1,103 @@
 package basiq
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
 	"net/url"
 )
 
+// ConnectionStatus represents the state of the connection to the institution.
+type ConnectionStatus string
+
+const (
+	ConnectionStatusActive  ConnectionStatus = "active"
+	ConnectionStatusPending ConnectionStatus = "pending"
+	ConnectionStatusInvalid ConnectionStatus = "invalid"
+)
+
+func (s *ConnectionStatus) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*s = ConnectionStatus(v)
+	return err
+}
+
 type ConnectionList struct {
 	Type  string       `json:"type"`
 	Data  []Connection `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Links SelfLink     `json:"links"`
 }
 
 type Connection struct {
-	Type        string `json:"type"`
-	ID          string `json:"id"`
-	Method      string `json:"method"`
-	CreatedDate string `json:"createdDate"`
-	LastUsed    string `json:"lastUsed"`
-	Status      string `json:"status"`
-	Accounts    struct {
-		Type string `json:"type"`
-		Data []struct {
-			Type     string `json:"type"`
-			ID       string `json:"id"`
-			Name     string `json:"name"`
-			Currency string `json:"currency"`
-			Class    struct {
-				Type    string `json:"type"`
-				Product string `json:"product"`
-			} `json:"class"`
-			AccountNo      string `json:"accountNo"`
-			AvailableFunds string `json:"availableFunds"`
-			Balance        string `json:"balance"`
-			LastUpdated    string `json:"lastUpdated"`
-			Status         string `json:"status"`
-			Links          struct {
-				Transactions string `json:"transactions"`
-				Self         string `json:"self"`
-			} `json:"links"`
-		} `json:"data"`
-	} `json:"accounts"`
-	Institution struct {
-		Id    string `json:"id"`
-		Type  string `json:"type"`
-		Links struct {
-			Self string `json:"self"`
-		} `json:"links"`
-	} `json:"institution"`
-	Profile struct {
-		EmailAddresses    []string `json:"emailAddresses"`
-		FirstName         string   `json:"firstName"`
-		FullName          string   `json:"fullName"`
-		LastName          string   `json:"lastName"`
-		MiddleName        string   `json:"middleName"`
-		PhoneNumbers      []string `json:"phoneNumbers"`
-		PhysicalAddresses []struct {
-			AddressLine1     string `json:"addressLine1"`
-			AddressLine2     string `json:"addressLine2"`
-			AddressLine3     string `json:"addressLine3"`
-			City             string `json:"city"`
-			Country          string `json:"country"`
-			CountryCode      string `json:"countryCode"`
-			FormattedAddress string `json:"formattedAddress"`
-			Postcode         string `json:"postcode"`
-			State            string `json:"state"`
-		} `json:"physicalAddresses"`
-	} `json:"profile"`
-	Links struct {
-		Accounts     string `json:"accounts"`
-		Self         string `json:"self"`
-		Transactions string `json:"transactions"`
-		User         string `json:"user"`
-	} `json:"links"`
+	Type        string             `json:"type"`
+	ID          string             `json:"id"`
+	Method      string             `json:"method"`
+	CreatedDate Timestamp          `json:"createdDate"`
+	LastUsed    Timestamp          `json:"lastUsed"`
+	Status      ConnectionStatus   `json:"status"`
+	Accounts    ConnectionAccounts `json:"accounts"`
+	Institution InstitutionRef     `json:"institution"`
+	Profile     Profile            `json:"profile"`
+	Links       ConnectionLinks    `json:"links"`
+}
+
+// ConnectionAccounts holds the accounts retrieved by the connection. The embedded accounts don't carry
+// the connection and institution IDs, use Connection.AccountList to get them populated.
+type ConnectionAccounts struct {
+	Type string    `json:"type"`
+	Data []Account `json:"data"`
+}
+
+// InstitutionRef is a reference to the institution the resource belongs to.
+type InstitutionRef struct {
+	Type  string      `json:"type"`
+	ID    string      `json:"id"`
+	Links SourceLinks `json:"links"`
+}
+
+// Profile holds the account holder details retrieved from the institution.
+type Profile struct {
+	EmailAddresses    []string  `json:"emailAddresses"`
+	FirstName         string    `json:"firstName"`
+	FullName          string    `json:"fullName"`
+	LastName          string    `json:"lastName"`
+	MiddleName        string    `json:"middleName"`
+	PhoneNumbers      []string  `json:"phoneNumbers"`
+	PhysicalAddresses []Address `json:"physicalAddresses"`
+}
+
+type ConnectionLinks struct {
+	Accounts     string `json:"accounts"`
+	Self         string `json:"self"`
+	Transactions string `json:"transactions"`
+	User         string `json:"user"`
+}
+
+// AccountList returns the accounts of the connection with the connection and institution IDs filled in.
+func (c Connection) AccountList() []Account {
+	accounts := make([]Account, 0, len(c.Accounts.Data))
+	for _, account := range c.Accounts.Data {
+		if account.Connection == "" {
+			account.Connection = c.ID
+		}
+		if account.Institution == "" {
+			account.Institution = c.Institution.ID
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) Connection(ctx context.Context, userID, connectionID string) (Connection, error) {
+	if err := a.guard(ctx, userID, ConsentEntityAccounts); err != nil {
+		return Connection{}, err
+	}
+
 	connection, err := a.connection(ctx, userID, connectionID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return connection, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -92,8 +107,12 @@ func (a *API) Connection(ctx context.Context, userID, connectionID string) (Conn
 }
 
 func (a *API) Connections(ctx context.Context, userID string) ([]Connection, error) {
+	if err := a.guard(ctx, userID, ConsentEntityAccounts); err != nil {
+		return nil, err
+	}
+
 	connections, err := a.connections(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return connections, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -102,9 +121,51 @@ func (a *API) Connections(ctx context.Context, userID string) ([]Connection, err
 	return a.connections(ctx, userID)
 }
 
+// CreateConnection connects the user to the institution and returns the job retrieving the data, follow it with
+// WaitForJob. The secrets of the credentials are zeroed once the call is made, regardless of its result.
+func (a *API) CreateConnection(ctx context.Context, userID string, credentials Credentials) (Job, error) {
+	defer credentials.Zero()
+
+	payload, err := credentials.payload(true)
+	if err != nil {
+		return Job{}, err
+	}
+	defer zero(payload)
+
+	job, err := a.createConnection(ctx, userID, payload)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return job, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return Job{}, err
+	}
+	return a.createConnection(ctx, userID, payload)
+}
+
+// UpdateConnection replaces the credentials of the connection and returns the job verifying them. The institution
+// ID of the credentials is not used. The secrets of the credentials are zeroed once the call is made.
+func (a *API) UpdateConnection(ctx context.Context, userID, connectionID string, credentials Credentials) (Job, error) {
+	defer credentials.Zero()
+
+	payload, err := credentials.payload(false)
+	if err != nil {
+		return Job{}, err
+	}
+	defer zero(payload)
+
+	job, err := a.updateConnection(ctx, userID, connectionID, payload)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return job, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return Job{}, err
+	}
+	return a.updateConnection(ctx, userID, connectionID, payload)
+}
+
 func (a *API) RefreshConnection(ctx context.Context, userID, connectionID string) (Connection, error) {
 	connection, err := a.refreshConnection(ctx, userID, connectionID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return connection, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -115,7 +176,7 @@ func (a *API) RefreshConnection(ctx context.Context, userID, connectionID string
 
 func (a *API) RefreshConnections(ctx context.Context, userID string) ([]Connection, error) {
 	connections, err := a.refreshConnections(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return connections, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -126,7 +187,7 @@ func (a *API) RefreshConnections(ctx context.Context, userID string) ([]Connecti
 
 func (a *API) DeleteConnection(ctx context.Context, userID, connectionID string) error {
 	err := a.deleteConnection(ctx, userID, connectionID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -138,7 +199,7 @@ func (a *API) DeleteConnection(ctx context.Context, userID, connectionID string)
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) connection(ctx context.Context, userID, connectionID string) (Connection, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "connections", connectionID)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "connections", connectionID)
 	if err != nil {
 		return Connection{}, err
 	}
@@ -153,7 +214,7 @@ func (a *API) connection(ctx context.Context, userID, connectionID string) (Conn
 }
 
 func (a *API) connections(ctx context.Context, userID string) ([]Connection, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "connections")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "connections")
 	if err != nil {
 		return nil, err
 	}
@@ -170,8 +231,38 @@ func (a *API) connections(ctx context.Context, userID string) ([]Connection, err
 	return list.Data, nil
 }
 
+func (a *API) createConnection(ctx context.Context, userID string, payload []byte) (Job, error) {
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "connections")
+	if err != nil {
+		return Job{}, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodPost, callURL, bytes.NewReader(payload))
+	if err != nil {
+		return Job{}, err
+	}
+
+	var job Job
+	return job, json.Unmarshal(data, &job)
+}
+
+func (a *API) updateConnection(ctx context.Context, userID, connectionID string, payload []byte) (Job, error) {
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "connections", connectionID)
+	if err != nil {
+		return Job{}, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodPost, callURL, bytes.NewReader(payload))
+	if err != nil {
+		return Job{}, err
+	}
+
+	var job Job
+	return job, json.Unmarshal(data, &job)
+}
+
 func (a *API) refreshConnection(ctx context.Context, userID, connectionID string) (Connection, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "connections", connectionID, "refresh")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "connections", connectionID, "refresh")
 	if err != nil {
 		return Connection{}, err
 	}
@@ -186,7 +277,7 @@ func (a *API) refreshConnection(ctx context.Context, userID, connectionID string
 }
 
 func (a *API) refreshConnections(ctx context.Context, userID string) ([]Connection, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "connections", "refresh")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "connections", "refresh")
 	if err != nil {
 		return nil, err
 	}
@@ -204,7 +295,7 @@ func (a *API) refreshConnections(ctx context.Context, userID string) ([]Connecti
 }
 
 func (a *API) deleteConnection(ctx context.Context, userID, connectionID string) error {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "connections", connectionID)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "connections", connectionID)
 	if err != nil {
 		return err
 	}
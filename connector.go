@@ -7,69 +7,127 @@ import (
 	"net/url"
 )
 
+// ConnectorMethod represents the way Basiq retrieves data from the institution.
+type ConnectorMethod string
+
+const (
+	ConnectorMethodWeb         ConnectorMethod = "web"
+	ConnectorMethodOpenBanking ConnectorMethod = "open-banking"
+)
+
+func (m *ConnectorMethod) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*m = ConnectorMethod(v)
+	return err
+}
+
+// ConnectorStage represents the maturity of the connector.
+type ConnectorStage string
+
+const (
+	ConnectorStageAlpha ConnectorStage = "alpha"
+	ConnectorStageBeta  ConnectorStage = "beta"
+	ConnectorStageLive  ConnectorStage = "live"
+)
+
+func (s *ConnectorStage) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*s = ConnectorStage(v)
+	return err
+}
+
+// ConnectorTier represents the size of the institution, tier 1 being the major banks.
+type ConnectorTier string
+
+const (
+	ConnectorTier1 ConnectorTier = "1"
+	ConnectorTier2 ConnectorTier = "2"
+	ConnectorTier3 ConnectorTier = "3"
+	ConnectorTier4 ConnectorTier = "4"
+)
+
+func (t *ConnectorTier) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*t = ConnectorTier(v)
+	return err
+}
+
 type ConnectorList struct {
 	Type       string      `json:"type"`
 	TotalCount int         `json:"totalCount"`
 	Data       []Connector `json:"data"`
-	Links      struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Links      SelfLink    `json:"links"`
 }
 
 type Connector struct {
-	Type          string `json:"type"`
-	ID            string `json:"id"`
-	Status        string `json:"status"`
-	Method        string `json:"method"`
-	Authorization struct {
-		Meta struct {
-			ForgottenPasswordUrl    string `json:"forgotten_password_url"`
-			LoginIdCaption          string `json:"login_id_caption"`
-			PasswordCaption         string `json:"password_caption"`
-			SecondaryLoginIdCaption string `json:"secondary_login_id_caption"`
-			SecurityCodeCaption     string `json:"security_code_caption"`
-		} `json:"meta"`
-		Type string `json:"type"`
-	} `json:"authorization"`
-	Institution struct {
-		Type      string `json:"type"`
-		Name      string `json:"name"`
-		Country   string `json:"country"`
-		ShortName string `json:"shortName"`
-		Tier      string `json:"tier"`
-		Logo      struct {
-			Colors struct {
-				Primary string `json:"primary"`
-			} `json:"colors"`
-			Links struct {
-				Full   string `json:"full"`
-				Square string `json:"square"`
-			} `json:"links"`
-			Type string `json:"type"`
-		} `json:"logo"`
-	} `json:"institution"`
-
-	Scopes []string `json:"scopes"`
-	Stage  string   `json:"stage"`
-	Stats  struct {
-		AverageDurationMs struct {
-			RetrieveAccounts     int `json:"retrieveAccounts"`
-			RetrieveMeta         int `json:"retrieveMeta"`
-			RetrieveTransactions int `json:"retrieveTransactions"`
-			Total                int `json:"total"`
-			VerifyCredentials    int `json:"verifyCredentials"`
-		} `json:"averageDurationMs"`
-	} `json:"stats"`
-	Links struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Type          string                 `json:"type"`
+	ID            string                 `json:"id"`
+	Status        string                 `json:"status"`
+	Method        ConnectorMethod        `json:"method"`
+	Authorization ConnectorAuthorization `json:"authorization"`
+	Institution   ConnectorInstitution   `json:"institution"`
+	Scopes        []string               `json:"scopes"`
+	Stage         ConnectorStage         `json:"stage"`
+	Stats         ConnectorStats         `json:"stats"`
+	Links         SelfLink               `json:"links"`
+}
+
+type ConnectorAuthorization struct {
+	Meta AuthorizationMeta `json:"meta"`
+	Type string            `json:"type"`
+}
+
+// AuthorizationMeta holds the captions of the login form fields used by the institution.
+type AuthorizationMeta struct {
+	ForgottenPasswordUrl    string `json:"forgotten_password_url"`
+	LoginIdCaption          string `json:"login_id_caption"`
+	PasswordCaption         string `json:"password_caption"`
+	SecondaryLoginIdCaption string `json:"secondary_login_id_caption"`
+	SecurityCodeCaption     string `json:"security_code_caption"`
+}
+
+type ConnectorInstitution struct {
+	Type      string        `json:"type"`
+	Name      string        `json:"name"`
+	Country   string        `json:"country"`
+	ShortName string        `json:"shortName"`
+	Tier      ConnectorTier `json:"tier"`
+	Logo      Logo          `json:"logo"`
+}
+
+type Logo struct {
+	Colors LogoColors `json:"colors"`
+	Links  LogoLinks  `json:"links"`
+	Type   string     `json:"type"`
+}
+
+type LogoColors struct {
+	Primary string `json:"primary"`
+}
+
+type LogoLinks struct {
+	Full   string `json:"full"`
+	Square string `json:"square"`
+}
+
+type ConnectorStats struct {
+	AverageDurationMs StepDurations `json:"averageDurationMs"`
+}
+
+// StepDurations holds the duration of the individual job steps in milliseconds.
+type StepDurations struct {
+	RetrieveAccounts     int `json:"retrieveAccounts"`
+	RetrieveMeta         int `json:"retrieveMeta"`
+	RetrieveTransactions int `json:"retrieveTransactions"`
+	Total                int `json:"total"`
+	VerifyCredentials    int `json:"verifyCredentials"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) Connector(ctx context.Context, connectorID, method string) (Connector, error) {
 	connector, err := a.connector(ctx, connectorID, method)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return connector, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -80,7 +138,7 @@ func (a *API) Connector(ctx context.Context, connectorID, method string) (Connec
 
 func (a *API) Connectors(ctx context.Context) ([]Connector, error) {
 	connectors, err := a.connectors(ctx)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return connectors, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -92,7 +150,7 @@ func (a *API) Connectors(ctx context.Context) ([]Connector, error) {
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) connector(ctx context.Context, connectorID, method string) (Connector, error) {
-	callURL, err := url.JoinPath(baseURL, "connectors", connectorID, method)
+	callURL, err := url.JoinPath(a.baseURL, "connectors", connectorID, method)
 	if err != nil {
 		return Connector{}, err
 	}
@@ -107,7 +165,7 @@ func (a *API) connector(ctx context.Context, connectorID, method string) (Connec
 }
 
 func (a *API) connectors(ctx context.Context) ([]Connector, error) {
-	callURL, err := url.JoinPath(baseURL, "connectors")
+	callURL, err := url.JoinPath(a.baseURL, "connectors")
 	if err != nil {
 		return nil, err
 	}
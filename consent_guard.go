@@ -0,0 +1,130 @@
+package basiq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var defaultConsentCacheTTL = 5 * time.Minute
+
+var (
+	ErrConsentNotCovered       = errors.New("basiq consent doesn't cover the data")
+	ErrDataRetentionNotAllowed = errors.New("basiq consent doesn't allow data retention")
+)
+
+// Entities of the consent permissions checked by the consent guard.
+const (
+	ConsentEntityAccounts     = "accounts"
+	ConsentEntityTransactions = "transactions"
+	ConsentEntityIdentity     = "identity"
+)
+
+// ConsentError is returned by the consent guard when the user's consent doesn't allow the call. It wraps one of
+// ErrNoActiveConsent, ErrConsentNotCovered or ErrDataRetentionNotAllowed.
+type ConsentError struct {
+	UserID string
+	Entity string
+	Err    error
+}
+
+type cachedConsent struct {
+	consent  UserConsent
+	err      error
+	loadedAt time.Time
+}
+
+// Covers returns true when the consent contains a permission for the entity. Entities are compared case-insensitively
+// and regardless of their plural form.
+func (c UserConsent) Covers(entity string) bool {
+	for _, permission := range c.Data.Permissions {
+		if normalizeEntity(permission.Entity) == normalizeEntity(entity) {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidateConsent drops the cached consent of the user, the next guarded call loads it again. Call it when
+// the consent of the user changes (e.g. on the consent webhook events).
+func (a *API) InvalidateConsent(userID string) {
+	a.consentsM.Lock()
+	defer a.consentsM.Unlock()
+
+	delete(a.consents, userID)
+}
+
+// CheckDataRetention returns a *ConsentError when the active consent of the user doesn't allow retaining the data.
+// The check is done regardless of the consent guard mode.
+func (a *API) CheckDataRetention(ctx context.Context, userID string) error {
+	consent, err := a.activeConsent(ctx, userID)
+	if err != nil {
+		return &ConsentError{UserID: userID, Err: err}
+	}
+	if !consent.Data.RetainData {
+		return &ConsentError{UserID: userID, Err: ErrDataRetentionNotAllowed}
+	}
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (e *ConsentError) Error() string {
+	if e.Entity == "" {
+		return fmt.Sprintf("user %s: %s", e.UserID, e.Err)
+	}
+	return fmt.Sprintf("user %s, entity %s: %s", e.UserID, e.Entity, e.Err)
+}
+
+func (e *ConsentError) Unwrap() error {
+	return e.Err
+}
+
+// guard checks the active consent of the user covers all entities, it does nothing when the guard is disabled.
+func (a *API) guard(ctx context.Context, userID string, entities ...string) error {
+	if !a.consentGuard {
+		return nil
+	}
+
+	consent, err := a.activeConsent(ctx, userID)
+	if err != nil {
+		return &ConsentError{UserID: userID, Err: err}
+	}
+	for _, entity := range entities {
+		if !consent.Covers(entity) {
+			return &ConsentError{UserID: userID, Entity: entity, Err: ErrConsentNotCovered}
+		}
+	}
+	return nil
+}
+
+// activeConsent returns the cached active consent of the user and loads it when it's missing or stale.
+// Missing active consent is cached as well, so the guard doesn't hammer the API for users without consent.
+func (a *API) activeConsent(ctx context.Context, userID string) (UserConsent, error) {
+	a.consentsM.Lock()
+	cached, ok := a.consents[userID]
+	a.consentsM.Unlock()
+
+	now := time.Now()
+	if ok && now.Sub(cached.loadedAt) < a.consentTTL && (cached.err != nil || cached.consent.IsActive(now)) {
+		return cached.consent, cached.err
+	}
+
+	consent, err := a.UserConsent(ctx, userID)
+	if err != nil && !errors.Is(err, ErrNoActiveConsent) {
+		return UserConsent{}, err
+	}
+
+	a.consentsM.Lock()
+	a.consents[userID] = cachedConsent{consent: consent, err: err, loadedAt: now}
+	a.consentsM.Unlock()
+
+	return consent, err
+}
+
+func normalizeEntity(entity string) string {
+	entity = strings.ToLower(strings.TrimSpace(entity))
+	return strings.TrimSuffix(entity, "s")
+}
@@ -0,0 +1,62 @@
+package basiq_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/lukasaron/basiq-go"
+	"github.com/lukasaron/basiq-go/basiqtest"
+)
+
+func TestConsentGuardRejectsUncoveredCalls(t *testing.T) {
+	srv := basiqtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	counter := &callCounter{transport: srv.Client().Transport, calls: map[string]int{}, queries: map[string][]string{}}
+	config := srv.Config()
+	config.ConsentGuard = true
+	config.HTTPClient = &http.Client{Transport: counter}
+	api, err := basiq.NewAPI(config)
+	if err != nil {
+		t.Fatalf("NewAPI: %v", err)
+	}
+
+	covered := srv.AddUser(basiq.User{Email: "covered@example.com"})
+	srv.AddConsent(covered.ID, basiq.UserConsent{Data: basiq.ConsentData{Permissions: []basiq.ConsentPermission{
+		{Entity: basiq.ConsentEntityAccounts}, {Entity: basiq.ConsentEntityTransactions},
+	}}})
+	uncovered := srv.AddUser(basiq.User{Email: "uncovered@example.com"})
+	srv.AddConsent(uncovered.ID, basiq.UserConsent{Data: basiq.ConsentData{Permissions: []basiq.ConsentPermission{
+		{Entity: basiq.ConsentEntityTransactions},
+	}}})
+	connection := srv.AddConnection(uncovered.ID, basiq.Connection{})
+
+	ctx := context.Background()
+	if _, err = api.Connection(ctx, uncovered.ID, connection.ID); !errors.Is(err, basiq.ErrConsentNotCovered) {
+		t.Errorf("Connection returned %v, want %v", err, basiq.ErrConsentNotCovered)
+	}
+	if _, err = api.Connections(ctx, uncovered.ID); !errors.Is(err, basiq.ErrConsentNotCovered) {
+		t.Errorf("Connections returned %v, want %v", err, basiq.ErrConsentNotCovered)
+	}
+
+	_, err = api.CreateReport(ctx, basiq.ReportParams{
+		Template: basiq.ReportTemplateAffordability,
+		UserIDs:  []string{covered.ID, uncovered.ID},
+	})
+	var consentErr *basiq.ConsentError
+	if !errors.As(err, &consentErr) || consentErr.UserID != uncovered.ID {
+		t.Errorf("CreateReport returned %v, want the consent error of user %s", err, uncovered.ID)
+	}
+
+	connections := "/users/" + uncovered.ID + "/connections"
+	for _, path := range []string{connections, connections + "/" + connection.ID} {
+		if n := counter.count(http.MethodGet, path); n != 0 {
+			t.Errorf("GET %s sent %d times, want none", path, n)
+		}
+	}
+	if n := counter.count(http.MethodPost, "/reports"); n != 0 {
+		t.Errorf("POST /reports sent %d times, want none", n)
+	}
+}
@@ -0,0 +1,123 @@
+package basiq
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// Credentials are the institution login details of the user used to create or update a connection. The secrets are
+// kept in byte slices, so they can be zeroed once the call is made. Credentials never print or encode the login
+// details, so they can't leak into logs or errors.
+type Credentials struct {
+	InstitutionID    string
+	LoginID          string
+	Password         []byte
+	SecurityCode     []byte
+	SecondaryLoginID string
+}
+
+// Validate checks the login details required by all institutions are set.
+func (c Credentials) Validate() error {
+	switch {
+	case c.LoginID == "":
+		return errors.New("basiq credentials login ID is required")
+	case len(c.Password) == 0:
+		return errors.New("basiq credentials password is required")
+	default:
+		return nil
+	}
+}
+
+// Zero overwrites the secrets with zeros, the credentials can't be used anymore.
+func (c Credentials) Zero() {
+	zero(c.Password)
+	zero(c.SecurityCode)
+}
+
+func (c Credentials) String() string {
+	if c.InstitutionID == "" {
+		return "basiq.Credentials{redacted}"
+	}
+	return fmt.Sprintf("basiq.Credentials{InstitutionID: %s, redacted}", c.InstitutionID)
+}
+
+// Format prints the redacted credentials for all verbs, including %#v and %+v.
+func (c Credentials) Format(f fmt.State, _ rune) {
+	_, _ = fmt.Fprint(f, c.String())
+}
+
+// MarshalJSON encodes the redacted credentials, use payload to get the request body.
+func (c Credentials) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// payload encodes the request body without copying the secrets into strings, the caller has to zero the returned
+// payload after use. The institution is sent only when creating the connection.
+func (c Credentials) payload(withInstitution bool) ([]byte, error) {
+	if withInstitution && c.InstitutionID == "" {
+		return nil, errors.New("basiq credentials institution ID is required")
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	// the buffer never grows, so no copies of the secrets are left behind, escaping takes up to 6 bytes per byte
+	size := len(c.LoginID) + len(c.Password) + len(c.SecurityCode) + len(c.SecondaryLoginID) + len(c.InstitutionID)
+	buf := bytes.NewBuffer(make([]byte, 0, 6*size+128))
+	buf.WriteString(`{"loginId":`)
+	writeJSONString(buf, []byte(c.LoginID))
+	buf.WriteString(`,"password":`)
+	writeJSONString(buf, c.Password)
+	if len(c.SecurityCode) > 0 {
+		buf.WriteString(`,"securityCode":`)
+		writeJSONString(buf, c.SecurityCode)
+	}
+	if c.SecondaryLoginID != "" {
+		buf.WriteString(`,"secondaryLoginId":`)
+		writeJSONString(buf, []byte(c.SecondaryLoginID))
+	}
+	if withInstitution {
+		buf.WriteString(`,"institution":{"id":`)
+		writeJSONString(buf, []byte(c.InstitutionID))
+		buf.WriteString(`}`)
+	}
+	buf.WriteString(`}`)
+
+	return buf.Bytes(), nil
+}
+
+// writeJSONString writes the quoted and escaped value, invalid UTF-8 is replaced by the replacement character.
+func writeJSONString(buf *bytes.Buffer, value []byte) {
+	const hex = "0123456789abcdef"
+
+	buf.WriteByte('"')
+	for len(value) > 0 {
+		r, size := utf8.DecodeRune(value)
+		switch {
+		case r == '"' || r == '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(byte(r))
+		case r < 0x20:
+			buf.WriteString(`\u00`)
+			buf.WriteByte(hex[r>>4])
+			buf.WriteByte(hex[r&0xf])
+		case r == utf8.RuneError && size == 1:
+			buf.WriteString(`�`)
+		default:
+			buf.Write(value[:size])
+		}
+		value = value[size:]
+	}
+	buf.WriteByte('"')
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
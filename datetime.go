@@ -0,0 +1,271 @@
+package basiq
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	dateLayout  = "2006-01-02"
+	monthLayout = "2006-01"
+)
+
+// timestampLayouts lists all formats Basiq uses for date-time values across the API versions.
+// Layouts without a zone are interpreted in the Australia/Sydney location.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05.000",
+	dateLayout,
+}
+
+var sydney = loadSydney()
+
+func loadSydney() *time.Location {
+	loc, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		// tz database is not available, the standard time is the best guess
+		return time.FixedZone("AEST", 10*60*60)
+	}
+	return loc
+}
+
+// Sydney returns the Australia/Sydney location Basiq uses for all dates without an explicit zone.
+func Sydney() *time.Location {
+	return sydney
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// Timestamp represents a date-time value returned by Basiq. Empty and null values decode into the zero Timestamp.
+type Timestamp struct {
+	time.Time
+}
+
+// NewTimestamp wraps the given time.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// Sydney returns the timestamp in the Australia/Sydney location.
+func (t Timestamp) Sydney() time.Time {
+	return t.In(sydney)
+}
+
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquote(data)
+	if err != nil || !ok {
+		*t = Timestamp{}
+		return err
+	}
+
+	parsed, err := parseLayouts(s, timestampLayouts)
+	if err != nil {
+		return err
+	}
+	*t = Timestamp{Time: parsed}
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// Date represents a calendar day (e.g. a transaction post date). Values are kept at midnight in the
+// Australia/Sydney location. Empty and null values decode into the zero Date.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the calendar day the given time falls into in the Australia/Sydney location.
+func NewDate(t time.Time) Date {
+	t = t.In(sydney)
+	return Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, sydney)}
+}
+
+// ParseDate parses a date in any of the formats Basiq uses.
+func ParseDate(s string) (Date, error) {
+	t, err := parseLayouts(s, timestampLayouts)
+	if err != nil {
+		return Date{}, err
+	}
+	return NewDate(t), nil
+}
+
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Format(dateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquote(data)
+	if err != nil || !ok {
+		*d = Date{}
+		return err
+	}
+
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// Month represents a calendar month as used by the affordability, income and expense endpoints ("2006-01").
+// Empty and null values decode into the zero Month.
+type Month struct {
+	time.Time
+}
+
+// NewMonth returns the given calendar month.
+func NewMonth(year int, month time.Month) Month {
+	return Month{Time: time.Date(year, month, 1, 0, 0, 0, 0, sydney)}
+}
+
+// MonthOf returns the calendar month the given time falls into in the Australia/Sydney location.
+func MonthOf(t time.Time) Month {
+	t = t.In(sydney)
+	return NewMonth(t.Year(), t.Month())
+}
+
+// CurrentMonth returns the current calendar month in the Australia/Sydney location.
+func CurrentMonth() Month {
+	return MonthOf(time.Now())
+}
+
+// ParseMonth parses a month in the "2006-01" format, full dates are truncated to their month.
+func ParseMonth(s string) (Month, error) {
+	t, err := time.ParseInLocation(monthLayout, s, sydney)
+	if err == nil {
+		return Month{Time: t}, nil
+	}
+
+	d, dErr := ParseDate(s)
+	if dErr != nil {
+		return Month{}, err
+	}
+	return MonthOf(d.Time), nil
+}
+
+// AddMonths returns the month n months after m, n can be negative.
+func (m Month) AddMonths(n int) Month {
+	return Month{Time: m.AddDate(0, n, 0)}
+}
+
+// First returns the first day of the month.
+func (m Month) First() Date {
+	return Date(m)
+}
+
+// Last returns the last day of the month.
+func (m Month) Last() Date {
+	return Date{Time: m.AddDate(0, 1, -1)}
+}
+
+func (m Month) String() string {
+	if m.IsZero() {
+		return ""
+	}
+	return m.Format(monthLayout)
+}
+
+func (m Month) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+func (m *Month) UnmarshalJSON(data []byte) error {
+	s, ok, err := unquote(data)
+	if err != nil || !ok {
+		*m = Month{}
+		return err
+	}
+
+	parsed, err := ParseMonth(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// monthRange is the wire representation of the params shared by the affordability, income and expense endpoints.
+// Unset months are omitted from the payload.
+type monthRange struct {
+	Accounts  []string `json:"accounts,omitempty"`
+	FromMonth *Month   `json:"fromMonth,omitempty"`
+	ToMonth   *Month   `json:"toMonth,omitempty"`
+}
+
+func newMonthRange(accounts []string, from, to Month) monthRange {
+	r := monthRange{Accounts: accounts}
+	if !from.IsZero() {
+		r.FromMonth = &from
+	}
+	if !to.IsZero() {
+		r.ToMonth = &to
+	}
+	return r
+}
+
+func validateMonthRange(from, to Month) error {
+	current := CurrentMonth()
+	switch {
+	case !from.IsZero() && from.After(current.Time):
+		return fmt.Errorf("fromMonth %s is in the future", from)
+	case !to.IsZero() && to.After(current.Time):
+		return fmt.Errorf("toMonth %s is in the future", to)
+	case !from.IsZero() && !to.IsZero() && from.After(to.Time):
+		return fmt.Errorf("fromMonth %s is after toMonth %s", from, to)
+	default:
+		return nil
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// unquote returns the string value of a JSON string, ok is false for null and empty values.
+func unquote(data []byte) (string, bool, error) {
+	if bytes.Equal(data, []byte("null")) {
+		return "", false, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", false, err
+	}
+	return s, s != "", nil
+}
+
+func parseLayouts(s string, layouts []string) (time.Time, error) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, sydney); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("unsupported date format: " + s)
+}
@@ -0,0 +1,245 @@
+package basiq_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+func TestTimestampUnmarshalJSON(t *testing.T) {
+	sydney := basiq.Sydney()
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"RFC3339 with nanoseconds", `"2024-03-01T10:00:00.123456789Z"`, time.Date(2024, 3, 1, 10, 0, 0, 123456789, time.UTC), false},
+		{"RFC3339 with offset", `"2024-03-01T10:00:00+11:00"`, time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC), false},
+		{"without zone", `"2024-03-01T10:00:00"`, time.Date(2024, 3, 1, 10, 0, 0, 0, sydney), false},
+		{"space separated", `"2024-03-01 10:00:00"`, time.Date(2024, 3, 1, 10, 0, 0, 0, sydney), false},
+		{"milliseconds without zone", `"2024-03-01T10:00:00.250"`, time.Date(2024, 3, 1, 10, 0, 0, 250e6, sydney), false},
+		{"date only", `"2024-03-01"`, time.Date(2024, 3, 1, 0, 0, 0, 0, sydney), false},
+		{"empty string", `""`, time.Time{}, false},
+		{"null", `null`, time.Time{}, false},
+		{"invalid date", `"yesterday"`, time.Time{}, true},
+		{"not a string", `42`, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got basiq.Timestamp
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal error = %v, want error %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) || got.IsZero() != tt.want.IsZero() {
+				t.Errorf("Unmarshal = %s, want %s", got.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestampMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input basiq.Timestamp
+		want  string
+	}{
+		{"zero", basiq.Timestamp{}, `""`},
+		{"nanoseconds", basiq.NewTimestamp(time.Date(2024, 3, 1, 10, 0, 0, 5, time.UTC)), `"2024-03-01T10:00:00.000000005Z"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.input)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateUnmarshalJSON(t *testing.T) {
+	sydney := basiq.Sydney()
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"date", `"2024-03-01"`, time.Date(2024, 3, 1, 0, 0, 0, 0, sydney), false},
+		{"UTC evening is the next Sydney day", `"2024-03-01T23:30:00Z"`, time.Date(2024, 3, 2, 0, 0, 0, 0, sydney), false},
+		{"time without zone", `"2024-03-01T23:30:00"`, time.Date(2024, 3, 1, 0, 0, 0, 0, sydney), false},
+		{"empty string", `""`, time.Time{}, false},
+		{"null", `null`, time.Time{}, false},
+		{"invalid date", `"2024-02-30"`, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got basiq.Date
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal error = %v, want error %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) || got.IsZero() != tt.want.IsZero() {
+				t.Errorf("Unmarshal = %s, want %s", got.Time, tt.want)
+			}
+		})
+	}
+}
+
+func TestSydneyDaylightSavingBoundaries(t *testing.T) {
+	if basiq.Sydney().String() != "Australia/Sydney" {
+		t.Skip("tz database is not available")
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		wantOffset time.Duration
+	}{
+		{"last day of daylight saving time", `"2024-04-06"`, 11 * time.Hour},
+		{"first day of standard time", `"2024-04-07"`, 11 * time.Hour},
+		{"day after the switch to standard time", `"2024-04-08"`, 10 * time.Hour},
+		{"first day of daylight saving time", `"2024-10-06"`, 10 * time.Hour},
+		{"day after the switch to daylight saving time", `"2024-10-07"`, 11 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got basiq.Date
+			if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if got.Hour() != 0 || got.Minute() != 0 {
+				t.Errorf("date %s isn't at midnight", got.Time)
+			}
+			if _, offset := got.Zone(); time.Duration(offset)*time.Second != tt.wantOffset {
+				t.Errorf("offset %s, want %s", time.Duration(offset)*time.Second, tt.wantOffset)
+			}
+			if s := got.String(); `"`+s+`"` != tt.input {
+				t.Errorf("String = %s, want %s", s, tt.input)
+			}
+		})
+	}
+
+	// 13:30 UTC is already the next day in Sydney during both standard and daylight saving time
+	if got := basiq.NewDate(time.Date(2024, 4, 6, 13, 30, 0, 0, time.UTC)).String(); got != "2024-04-07" {
+		t.Errorf("NewDate = %s, want 2024-04-07", got)
+	}
+	if got := basiq.NewDate(time.Date(2024, 10, 6, 14, 30, 0, 0, time.UTC)).String(); got != "2024-10-07" {
+		t.Errorf("NewDate = %s, want 2024-10-07", got)
+	}
+}
+
+func TestMonthUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    basiq.Month
+		wantErr bool
+	}{
+		{"month", `"2024-03"`, basiq.NewMonth(2024, time.March), false},
+		{"full date is truncated", `"2024-03-15"`, basiq.NewMonth(2024, time.March), false},
+		{"timestamp is truncated", `"2024-03-31T23:30:00"`, basiq.NewMonth(2024, time.March), false},
+		{"empty string", `""`, basiq.Month{}, false},
+		{"null", `null`, basiq.Month{}, false},
+		{"invalid month", `"2024-13"`, basiq.Month{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got basiq.Month
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal error = %v, want error %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want.Time) || got.IsZero() != tt.want.IsZero() {
+				t.Errorf("Unmarshal = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMonthBounds(t *testing.T) {
+	tests := []struct {
+		month     basiq.Month
+		wantFirst string
+		wantLast  string
+	}{
+		{basiq.NewMonth(2024, time.February), "2024-02-01", "2024-02-29"},
+		{basiq.NewMonth(2023, time.February), "2023-02-01", "2023-02-28"},
+		{basiq.NewMonth(2024, time.January).AddMonths(-1), "2023-12-01", "2023-12-31"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.month.String(), func(t *testing.T) {
+			if first := tt.month.First().String(); first != tt.wantFirst {
+				t.Errorf("First = %s, want %s", first, tt.wantFirst)
+			}
+			if last := tt.month.Last().String(); last != tt.wantLast {
+				t.Errorf("Last = %s, want %s", last, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestMonthRangeValidate(t *testing.T) {
+	current := basiq.CurrentMonth()
+	tests := []struct {
+		name    string
+		from    basiq.Month
+		to      basiq.Month
+		wantErr bool
+	}{
+		{"unset months", basiq.Month{}, basiq.Month{}, false},
+		{"past range", current.AddMonths(-6), current.AddMonths(-1), false},
+		{"range ending with the current month", current.AddMonths(-1), current, false},
+		{"fromMonth in the future", current.AddMonths(1), basiq.Month{}, true},
+		{"toMonth in the future", basiq.Month{}, current.AddMonths(1), true},
+		{"fromMonth after toMonth", current.AddMonths(-1), current.AddMonths(-2), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := basiq.IncomeSummaryParams{FromMonth: tt.from, ToMonth: tt.to}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMonthRangeMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		params basiq.AffordabilityParams
+		want   string
+	}{
+		{"unset months are omitted", basiq.AffordabilityParams{}, `{}`},
+		{
+			name:   "all fields",
+			params: basiq.AffordabilityParams{Accounts: []string{"account-1"}, FromMonth: basiq.NewMonth(2024, time.January), ToMonth: basiq.NewMonth(2024, time.March)},
+			want:   `{"accounts":["account-1"],"fromMonth":"2024-01","toMonth":"2024-03"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.params)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
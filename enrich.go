@@ -0,0 +1,191 @@
+package basiq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+const defaultEnrichConcurrency = 4
+
+// EnrichParams describes a transaction from any source to be enriched, only Description is required.
+type EnrichParams struct {
+	Description string
+	// Country is the ISO 3166 code of the country, e.g. AU.
+	Country     string
+	Institution string
+	// MCC is the merchant category code of the card transaction.
+	MCC       string
+	Direction TransactionDirection
+}
+
+// Validate checks the description is set.
+func (p EnrichParams) Validate() error {
+	if p.Description == "" {
+		return errors.New("basiq enrich description is required")
+	}
+	return nil
+}
+
+func (p EnrichParams) query() string {
+	values := url.Values{"q": {p.Description}}
+	if p.Country != "" {
+		values.Set("country", p.Country)
+	}
+	if p.Institution != "" {
+		values.Set("institution", p.Institution)
+	}
+	if p.MCC != "" {
+		values.Set("mcc", p.MCC)
+	}
+	if p.Direction != "" {
+		values.Set("direction", string(p.Direction))
+	}
+	return values.Encode()
+}
+
+type EnrichResponse struct {
+	Type  string     `json:"type"`
+	Data  Enrichment `json:"data"`
+	Links SelfLink   `json:"links"`
+}
+
+// EnrichResult is the result of a single description of the batch, Err is set when the enrichment has failed.
+type EnrichResult struct {
+	Params     EnrichParams
+	Enrichment Enrichment
+	Err        error
+}
+
+// Enrichment holds the merchant, category and location details Basiq derived from the transaction description.
+type Enrichment struct {
+	Category EnrichmentCategory `json:"category"`
+	Location Location           `json:"location"`
+	Merchant Merchant           `json:"merchant"`
+}
+
+type EnrichmentCategory struct {
+	Anzsic AnzsicCategory `json:"anzsic"`
+}
+
+// AnzsicCategory represents the Australian and New Zealand Standard Industrial Classification of the merchant.
+type AnzsicCategory struct {
+	Class       AnzsicCode `json:"class"`
+	Division    AnzsicCode `json:"division"`
+	Group       AnzsicCode `json:"group"`
+	Subdivision AnzsicCode `json:"subdivision"`
+}
+
+type AnzsicCode struct {
+	Code  string `json:"code"`
+	Title string `json:"title"`
+}
+
+type Location struct {
+	Country          string   `json:"country"`
+	FormattedAddress string   `json:"formattedAddress"`
+	Geometry         Geometry `json:"geometry"`
+	PostalCode       string   `json:"postalCode"`
+	Route            string   `json:"route"`
+	RouteNo          string   `json:"routeNo"`
+	State            string   `json:"state"`
+	Suburb           string   `json:"suburb"`
+}
+
+type Geometry struct {
+	Lat string `json:"lat"`
+	Lng string `json:"lng"`
+}
+
+type Merchant struct {
+	ID           string      `json:"id"`
+	BusinessName string      `json:"businessName"`
+	ABN          int64       `json:"ABN"`
+	LogoMaster   string      `json:"logoMaster"`
+	LogoThumb    string      `json:"logoThumb"`
+	PhoneNumber  PhoneNumber `json:"phoneNumber"`
+	Website      string      `json:"website"`
+}
+
+type PhoneNumber struct {
+	International string `json:"international"`
+	Local         string `json:"local"`
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// Enrich returns the merchant, category and location details of the transaction description, the same details
+// Basiq provides in Transaction.Enrich.
+func (a *API) Enrich(ctx context.Context, params EnrichParams) (Enrichment, error) {
+	if err := params.Validate(); err != nil {
+		return Enrichment{}, err
+	}
+
+	enrichment, err := a.enrich(ctx, params)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return enrichment, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return Enrichment{}, err
+	}
+	return a.enrich(ctx, params)
+}
+
+// EnrichBatch enriches all descriptions with at most concurrency calls in flight, 4 calls are used when
+// concurrency is not positive. The results are in the order of the params, failures are reported per result,
+// so a single failed description doesn't discard the others. Descriptions not started before the context is done
+// fail with the context error.
+func (a *API) EnrichBatch(ctx context.Context, params []EnrichParams, concurrency int) []EnrichResult {
+	if concurrency <= 0 {
+		concurrency = defaultEnrichConcurrency
+	}
+
+	results := make([]EnrichResult, len(params))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range params {
+		results[i].Params = params[i]
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *EnrichResult) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			result.Enrichment, result.Err = a.Enrich(ctx, result.Params)
+		}(&results[i])
+	}
+
+	wg.Wait()
+	return results
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (a *API) enrich(ctx context.Context, params EnrichParams) (Enrichment, error) {
+	callURL, err := url.JoinPath(a.baseURL, "enrich")
+	if err != nil {
+		return Enrichment{}, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodGet, callURL+"?"+params.query(), nil)
+	if err != nil {
+		return Enrichment{}, err
+	}
+
+	var response EnrichResponse
+	if err = json.Unmarshal(data, &response); err != nil {
+		return Enrichment{}, err
+	}
+	return response.Data, nil
+}
@@ -0,0 +1,29 @@
+package basiq
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// decodeEnum reads an enumerated value in a tolerant way. Strings are trimmed, numbers are kept in their textual
+// form and null produces an empty value. Unknown values are preserved, so they can still be inspected by the caller.
+func decodeEnum(data []byte) (string, error) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return "", nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(s), nil
+	default:
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return "", err
+		}
+		return n.String(), nil
+	}
+}
@@ -8,18 +8,23 @@ import (
 
 type Error struct {
 	HttpCode      int
-	Type          string `json:"type"`
-	CorrelationId string `json:"correlationId"`
-	Data          []struct {
-		Code   string `json:"code"`
-		Detail string `json:"detail"`
-		Source struct {
-			Parameter string `json:"parameter"`
-			Pointer   string `json:"pointer"`
-		} `json:"source"`
-		Title string `json:"title"`
-		Type  string `json:"type"`
-	} `json:"data"`
+	Type          string      `json:"type"`
+	CorrelationId string      `json:"correlationId"`
+	Data          []ErrorData `json:"data"`
+}
+
+type ErrorData struct {
+	Code   string      `json:"code"`
+	Detail string      `json:"detail"`
+	Source ErrorSource `json:"source"`
+	Title  string      `json:"title"`
+	Type   string      `json:"type"`
+}
+
+// ErrorSource points to the part of the request that caused the error.
+type ErrorSource struct {
+	Parameter string `json:"parameter"`
+	Pointer   string `json:"pointer"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
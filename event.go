@@ -1,71 +1,289 @@
 package basiq
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
-	"net/http"
+	"fmt"
 	"net/url"
+	"strings"
 )
 
+// EventEntity represents the kind of the resource the event is about.
+type EventEntity string
+
+const (
+	EventEntityUser         EventEntity = "user"
+	EventEntityConnection   EventEntity = "connection"
+	EventEntityAccount      EventEntity = "account"
+	EventEntityTransactions EventEntity = "transactions"
+	EventEntityConsent      EventEntity = "consent"
+	EventEntityPayRequest   EventEntity = "payrequest"
+	EventEntityPayout       EventEntity = "payout"
+)
+
+func (e *EventEntity) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*e = EventEntity(v)
+	return err
+}
+
+// EventParams filters the events, empty fields are not used. CreatedSince keeps the events created at or after
+// the timestamp.
+type EventParams struct {
+	Entity       EventEntity
+	EventType    string
+	UserID       string
+	CreatedSince Timestamp
+}
+
+func (p EventParams) filter() string {
+	var filters []string
+	if p.Entity != "" {
+		filters = append(filters, fmt.Sprintf("event.entity.eq('%s')", p.Entity))
+	}
+	if p.EventType != "" {
+		filters = append(filters, fmt.Sprintf("event.type.eq('%s')", p.EventType))
+	}
+	if p.UserID != "" {
+		filters = append(filters, fmt.Sprintf("user.id.eq('%s')", p.UserID))
+	}
+	if !p.CreatedSince.IsZero() {
+		filters = append(filters, fmt.Sprintf("event.createdDate.gteq('%s')", p.CreatedSince))
+	}
+	return strings.Join(filters, ",")
+}
+
 type EventList struct {
-	Type  string  `json:"type"`
-	Data  []Event `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Type  string    `json:"type"`
+	Data  []Event   `json:"data"`
+	Links PageLinks `json:"links"`
 }
 
+// Event represents a change of a resource. The payload of the event is decoded according to its Entity.
 type Event struct {
-	Type        string `json:"type"`
-	ID          string `json:"id"`
-	CreatedDate string `json:"createdDate"`
-	Entity      string `json:"entity"`
-	EventType   string `json:"eventType"`
-	UserId      string `json:"userId"`
-	DataRef     string `json:"dataRef"`
-	Data        []struct {
-		Email string `json:"email"`
-		ID    string `json:"id"`
-		Links struct {
-			Self string `json:"self"`
-		} `json:"links"`
-		Mobile string `json:"mobile"`
-		Type   string `json:"type"`
-	} `json:"data"`
-	Links []struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Type        string      `json:"type"`
+	ID          string      `json:"id"`
+	CreatedDate Timestamp   `json:"createdDate"`
+	Entity      EventEntity `json:"entity"`
+	EventType   string      `json:"eventType"`
+	UserId      string      `json:"userId"`
+	DataRef     string      `json:"dataRef"`
+	Data        EventData   `json:"data"`
+	Links       EventLinks  `json:"links"`
+}
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type event Event
+	var v event
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*e = Event(v)
+	if err := e.Data.decode(e.Entity); err != nil {
+		// a malformed payload mustn't fail the whole event list, the raw payload is kept with the error
+		e.Data = EventData{Raw: e.Data.Raw, Err: fmt.Errorf("basiq event %s data can't be decoded: %w", e.ID, err)}
+	}
+	return nil
+}
+
+// EventData holds the payload of the event. Only the field matching the entity of the event is set, Raw keeps
+// the original payload, so events of unknown entities can still be processed. Err is set when the payload can't
+// be decoded into the field of the entity, Raw is kept in that case as well.
+type EventData struct {
+	Raw          json.RawMessage
+	Err          error
+	User         *User
+	Connection   *Connection
+	Account      *Account
+	Transactions []Transaction
+	Consent      *UserConsent
+	PayRequest   *PayRequest
+	Payout       *Payout
+}
+
+func (d EventData) MarshalJSON() ([]byte, error) {
+	if len(d.Raw) == 0 {
+		return []byte("null"), nil
+	}
+	return d.Raw, nil
+}
+
+func (d *EventData) UnmarshalJSON(data []byte) error {
+	*d = EventData{Raw: append(json.RawMessage(nil), data...)}
+	return nil
+}
+
+// EventLinks holds the links of the event. Older API versions send the links as an array, both forms are accepted.
+type EventLinks struct {
+	Self string `json:"self"`
+	Data string `json:"data,omitempty"`
+}
+
+func (l *EventLinks) UnmarshalJSON(data []byte) error {
+	type links EventLinks
+	var list []links
+	if err := unmarshalOneOrMany(data, &list); err != nil {
+		return err
+	}
+
+	*l = EventLinks{}
+	if len(list) > 0 {
+		*l = EventLinks(list[0])
+	}
+	return nil
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) Events(ctx context.Context) ([]Event, error) {
-	events, err := a.events(ctx)
-	if err != nil && !IsUnauthorizedErr(err) {
+	return a.FilteredEvents(ctx, EventParams{})
+}
+
+func (a *API) FilteredEvents(ctx context.Context, params EventParams) ([]Event, error) {
+	events, err := a.events(ctx, params)
+	if err == nil || !IsUnauthorizedErr(err) {
 		return events, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
 		return nil, err
 	}
-	return a.events(ctx)
+	return a.events(ctx, params)
+}
+
+// ResolveEvent fetches the current state of the resource the event refers to by its DataRef. The returned data has
+// the field matching the resource set, e.g. User for "/users/{id}" or Connection for "/users/{id}/connections/{id}".
+func (a *API) ResolveEvent(ctx context.Context, event Event) (EventData, error) {
+	ref, err := url.Parse(event.DataRef)
+	if err != nil {
+		return EventData{}, err
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(ref.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	var data EventData
+	switch {
+	case len(segments) == 2 && segments[0] == "users":
+		user, err := a.User(ctx, segments[1])
+		data.User = &user
+		return data, err
+	case len(segments) == 4 && segments[0] == "users" && segments[2] == "connections":
+		connection, err := a.Connection(ctx, segments[1], segments[3])
+		data.Connection = &connection
+		return data, err
+	case len(segments) == 4 && segments[0] == "users" && segments[2] == "accounts":
+		account, err := a.Account(ctx, segments[1], segments[3])
+		data.Account = &account
+		return data, err
+	case len(segments) == 4 && segments[0] == "users" && segments[2] == "transactions":
+		transaction, err := a.Transaction(ctx, segments[1], segments[3])
+		data.Transactions = []Transaction{transaction}
+		return data, err
+	case len(segments) == 3 && segments[0] == "users" && segments[2] == "transactions":
+		data.Transactions, err = a.Transactions(ctx, segments[1])
+		return data, err
+	case (len(segments) == 3 || len(segments) == 4) && segments[0] == "users" && segments[2] == "consents":
+		// the consent of the event may have been revoked or expired, so it's looked up in all consents
+		consents, err := a.UserConsents(ctx, segments[1])
+		if err != nil {
+			return EventData{}, err
+		}
+		var consentID string
+		if len(segments) == 4 {
+			consentID = segments[3]
+		}
+		consent, ok := findConsent(consents, consentID)
+		if !ok {
+			return EventData{}, fmt.Errorf("basiq consent of the event not found: %s", event.DataRef)
+		}
+		data.Consent = &consent
+		return data, nil
+	case len(segments) == 3 && segments[0] == "payments" && segments[1] == "payrequests":
+		payRequest, err := a.PayRequest(ctx, segments[2])
+		data.PayRequest = &payRequest
+		return data, err
+	case len(segments) == 3 && segments[0] == "payments" && segments[1] == "payouts":
+		payout, err := a.Payout(ctx, segments[2])
+		data.Payout = &payout
+		return data, err
+	default:
+		return EventData{}, fmt.Errorf("basiq event data reference can't be resolved: %s", event.DataRef)
+	}
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
-func (a *API) events(ctx context.Context) ([]Event, error) {
-	callURL, err := url.JoinPath(baseURL, "events")
+func (a *API) events(ctx context.Context, params EventParams) ([]Event, error) {
+	callURL, err := url.JoinPath(a.baseURL, "events")
 	if err != nil {
 		return nil, err
 	}
+	if filter := params.filter(); filter != "" {
+		callURL += "?" + url.Values{"filter": {filter}}.Encode()
+	}
 
-	data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
-	if err != nil {
-		return nil, err
+	return listPages[Event](ctx, a, callURL)
+}
+
+// decode fills the payload field matching the entity.
+func (d *EventData) decode(entity EventEntity) error {
+	if len(d.Raw) == 0 || string(d.Raw) == "null" {
+		return nil
 	}
 
-	var list EventList
-	if err = json.Unmarshal(data, &list); err != nil {
-		return nil, err
+	switch entity {
+	case EventEntityUser:
+		return decodeOne(d.Raw, &d.User)
+	case EventEntityConnection:
+		return decodeOne(d.Raw, &d.Connection)
+	case EventEntityAccount:
+		return decodeOne(d.Raw, &d.Account)
+	case EventEntityTransactions:
+		return unmarshalOneOrMany(d.Raw, &d.Transactions)
+	case EventEntityConsent:
+		return decodeOne(d.Raw, &d.Consent)
+	case EventEntityPayRequest:
+		return decodeOne(d.Raw, &d.PayRequest)
+	case EventEntityPayout:
+		return decodeOne(d.Raw, &d.Payout)
+	default:
+		return nil
+	}
+}
+
+// decodeOne decodes a single resource, which may be wrapped in an array.
+func decodeOne[T any](data []byte, target **T) error {
+	var list []T
+	if err := unmarshalOneOrMany(data, &list); err != nil {
+		return err
+	}
+	if len(list) > 0 {
+		*target = &list[0]
+	}
+	return nil
+}
+
+// unmarshalOneOrMany decodes both a single object and an array of objects into the slice.
+func unmarshalOneOrMany[T any](data []byte, target *[]T) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*target = nil
+		return nil
+	case len(data) > 0 && data[0] == '[':
+		return json.Unmarshal(data, target)
+	default:
+		var v T
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		*target = []T{v}
+		return nil
 	}
-	return list.Data, nil
 }
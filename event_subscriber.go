@@ -0,0 +1,186 @@
+package basiq
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	defaultEventInterval  = 30 * time.Second
+	eventRetryInterval    = time.Second
+	eventMaxRetryInterval = 5 * time.Minute
+)
+
+// EventHandlerFunc processes a single event. Returned error stops the subscriber, the event is delivered again
+// once the subscriber runs again. The event is delivered again as well when the subscriber stops before its
+// checkpoint is saved, so the handler has to be idempotent.
+type EventHandlerFunc func(ctx context.Context, event Event) error
+
+// EventSubscriberConfig represents the set of input parameters of the event subscriber.
+type EventSubscriberConfig struct {
+	// Name identifies the checkpoint of the subscriber in the store.
+	Name string
+	// Filter limits the events delivered to the handler.
+	Filter EventParams
+	// Interval is the pause between two polls, defaults to 30 seconds.
+	Interval time.Duration
+	Store    CheckpointStore
+	Handler  EventHandlerFunc
+}
+
+// Validate checks all necessary input parameters and returns error when some of them are not set.
+func (c EventSubscriberConfig) Validate() error {
+	switch {
+	case c.Name == "":
+		return errors.New("basiq event subscriber name is required")
+	case c.Store == nil:
+		return errors.New("basiq event subscriber checkpoint store is required")
+	case c.Handler == nil:
+		return errors.New("basiq event subscriber handler is required")
+	case c.Interval < 0:
+		return errors.New("basiq event subscriber interval can't be negative")
+	default:
+		return nil
+	}
+}
+
+// EventSubscriber polls the events and delivers them to the handler at least once, in the order the events have
+// been created. The position of the subscriber is persisted in the checkpoint store after every delivered event,
+// so a restarted subscriber continues where the previous one stopped. An event handled right before a crash or
+// a failed save of the checkpoint is delivered again, handlers have to tolerate the duplicates (e.g. by the event ID).
+type EventSubscriber struct {
+	api      *API
+	name     string
+	filter   EventParams
+	interval time.Duration
+	store    CheckpointStore
+	handler  EventHandlerFunc
+}
+
+// NewEventSubscriber instantiates the subscriber and checks all input parameters.
+func NewEventSubscriber(api *API, config EventSubscriberConfig) (*EventSubscriber, error) {
+	if api == nil {
+		return nil, errors.New("basiq API is required")
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	interval := config.Interval
+	if interval == 0 {
+		interval = defaultEventInterval
+	}
+
+	return &EventSubscriber{
+		api:      api,
+		name:     config.Name,
+		filter:   config.Filter,
+		interval: interval,
+		store:    config.Store,
+		handler:  config.Handler,
+	}, nil
+}
+
+// Run polls the events until the context is done, or the handler or the checkpoint store fails. Failed fetches of
+// the events (e.g. network errors or 5xx responses) are retried with an exponential backoff capped at 5 minutes.
+func (s *EventSubscriber) Run(ctx context.Context) error {
+	var retry time.Duration
+	for {
+		wait := s.interval
+
+		checkpoint, err := s.store.Load(ctx, s.name)
+		if err != nil {
+			return err
+		}
+
+		events, err := s.fetch(ctx, checkpoint)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return ctx.Err()
+		case err != nil:
+			retry = nextEventRetry(retry)
+			wait = retry
+		default:
+			retry = 0
+			if err = s.deliver(ctx, checkpoint, events); err != nil {
+				return err
+			}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Poll fetches the events once and delivers all events not covered by the stored checkpoint.
+func (s *EventSubscriber) Poll(ctx context.Context) error {
+	checkpoint, err := s.store.Load(ctx, s.name)
+	if err != nil {
+		return err
+	}
+
+	events, err := s.fetch(ctx, checkpoint)
+	if err != nil {
+		return err
+	}
+	return s.deliver(ctx, checkpoint, events)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// fetch lists the events created since the checkpoint in the order they have been created. The checkpoint
+// timestamp is included, as some of the events created at that moment may not have been delivered yet.
+func (s *EventSubscriber) fetch(ctx context.Context, checkpoint Checkpoint) ([]Event, error) {
+	filter := s.filter
+	if checkpoint.CreatedDate.After(filter.CreatedSince.Time) {
+		filter.CreatedSince = checkpoint.CreatedDate
+	}
+
+	events, err := s.api.FilteredEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].CreatedDate.Equal(events[j].CreatedDate.Time) {
+			return events[i].CreatedDate.Before(events[j].CreatedDate.Time)
+		}
+		return events[i].ID < events[j].ID
+	})
+	return events, nil
+}
+
+// deliver passes the events not covered by the checkpoint to the handler and saves the checkpoint after each one.
+func (s *EventSubscriber) deliver(ctx context.Context, checkpoint Checkpoint, events []Event) error {
+	for _, event := range events {
+		if checkpoint.Covers(event) {
+			continue
+		}
+		if err := s.handler(ctx, event); err != nil {
+			return err
+		}
+		checkpoint = checkpoint.Advance(event)
+		if err := s.store.Save(ctx, s.name, checkpoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func nextEventRetry(retry time.Duration) time.Duration {
+	if retry == 0 {
+		return eventRetryInterval
+	}
+	retry *= 2
+	if retry > eventMaxRetryInterval {
+		retry = eventMaxRetryInterval
+	}
+	return retry
+}
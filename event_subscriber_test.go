@@ -0,0 +1,90 @@
+package basiq_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+func TestEventSubscriberRunRetriesFailedFetch(t *testing.T) {
+	api, srv, _ := newTestAPI(t)
+	srv.AddEvent(basiq.EventEntityUser, "created", "user-1", "", nil)
+	srv.AddEvent(basiq.EventEntityUser, "updated", "user-1", "", nil)
+	srv.InjectError(http.MethodGet, "/events", http.StatusServiceUnavailable, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var delivered []string
+	subscriber, err := basiq.NewEventSubscriber(api, basiq.EventSubscriberConfig{
+		Name:     "test",
+		Interval: 10 * time.Millisecond,
+		Store:    basiq.NewMemoryCheckpointStore(),
+		Handler: func(_ context.Context, event basiq.Event) error {
+			delivered = append(delivered, event.EventType)
+			if len(delivered) == 2 {
+				cancel()
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewEventSubscriber: %v", err)
+	}
+
+	if err = subscriber.Run(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run: %v, want context.Canceled", err)
+	}
+	if strings.Join(delivered, ",") != "created,updated" {
+		t.Errorf("delivered %v, want [created updated]", delivered)
+	}
+}
+
+func TestEventSubscriberPollFiltersByCheckpoint(t *testing.T) {
+	api, srv, counter := newTestAPI(t)
+	srv.AddEvent(basiq.EventEntityUser, "created", "user-1", "", nil)
+
+	var delivered []basiq.Event
+	store := basiq.NewMemoryCheckpointStore()
+	subscriber, err := basiq.NewEventSubscriber(api, basiq.EventSubscriberConfig{
+		Name:  "test",
+		Store: store,
+		Handler: func(_ context.Context, event basiq.Event) error {
+			delivered = append(delivered, event)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewEventSubscriber: %v", err)
+	}
+
+	ctx := context.Background()
+	if err = subscriber.Poll(ctx); err != nil {
+		t.Fatalf("first Poll: %v", err)
+	}
+	if filter := counter.lastQuery(http.MethodGet, "/events").Get("filter"); filter != "" {
+		t.Errorf("first poll filtered by %q, want no filter", filter)
+	}
+
+	updated := srv.AddEvent(basiq.EventEntityUser, "updated", "user-1", "", nil)
+	if err = subscriber.Poll(ctx); err != nil {
+		t.Fatalf("second Poll: %v", err)
+	}
+
+	checkpoint, _ := store.Load(ctx, "test")
+	filter := counter.lastQuery(http.MethodGet, "/events").Get("filter")
+	if !strings.Contains(filter, "event.createdDate.gteq(") {
+		t.Errorf("second poll filtered by %q, want the createdDate filter", filter)
+	}
+	if len(delivered) != 2 || delivered[1].ID != updated.ID {
+		t.Fatalf("delivered %d events, want the created and the updated event", len(delivered))
+	}
+	if !checkpoint.Covers(updated) {
+		t.Errorf("checkpoint %+v doesn't cover the updated event", checkpoint)
+	}
+}
@@ -0,0 +1,60 @@
+package basiq_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+func TestEventListKeepsMalformedPayload(t *testing.T) {
+	data := []byte(`{"type":"list","data":[
+		{"type":"event","id":"event-1","entity":"user","eventType":"created","data":{"id":"user-1","email":"a@b.c"}},
+		{"type":"event","id":"event-2","entity":"user","eventType":"updated","data":{"id":42}}
+	]}`)
+
+	var list basiq.EventList
+	if err := json.Unmarshal(data, &list); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(list.Data) != 2 {
+		t.Fatalf("decoded %d events, want 2", len(list.Data))
+	}
+
+	if valid := list.Data[0].Data; valid.Err != nil || valid.User == nil || valid.User.ID != "user-1" {
+		t.Errorf("valid event decoded as %+v", valid)
+	}
+
+	malformed := list.Data[1].Data
+	if malformed.Err == nil {
+		t.Error("malformed event has no error")
+	}
+	if malformed.User != nil {
+		t.Errorf("malformed event has user %+v", malformed.User)
+	}
+	if string(malformed.Raw) != `{"id":42}` {
+		t.Errorf("malformed event raw payload %s", malformed.Raw)
+	}
+}
+
+func TestResolveEventOfRevokedConsent(t *testing.T) {
+	api, srv, _ := newTestAPI(t)
+	user := srv.AddUser(basiq.User{Email: "consent@example.com"})
+	revoked := srv.AddConsent(user.ID, basiq.UserConsent{Status: basiq.ConsentStatusRevoked})
+	srv.AddConsent(user.ID, basiq.UserConsent{Status: basiq.ConsentStatusActive})
+
+	event := srv.AddEvent(basiq.EventEntityConsent, "revoked", user.ID, "/users/"+user.ID+"/consents/"+revoked.ID, revoked)
+	data, err := api.ResolveEvent(context.Background(), event)
+	if err != nil {
+		t.Fatalf("ResolveEvent: %v", err)
+	}
+	if data.Consent == nil || data.Consent.ID != revoked.ID || data.Consent.Status != basiq.ConsentStatusRevoked {
+		t.Errorf("resolved consent %+v, want the revoked consent %s", data.Consent, revoked.ID)
+	}
+
+	event = srv.AddEvent(basiq.EventEntityConsent, "revoked", user.ID, "/users/"+user.ID+"/consents/missing", revoked)
+	if _, err = api.ResolveEvent(context.Background(), event); err == nil {
+		t.Error("ResolveEvent of a missing consent hasn't failed")
+	}
+}
@@ -8,89 +8,78 @@ import (
 	"net/url"
 )
 
+// ExpenseSummaryParams are encoded by MarshalJSON, unset months are omitted.
 type ExpenseSummaryParams struct {
-	Accounts  []string `json:"accounts,omitempty"`
-	FromMonth string   `json:"fromMonth,omitempty"`
-	ToMonth   string   `json:"toMonth,omitempty"`
+	Accounts  []string
+	FromMonth Month
+	ToMonth   Month
+}
+
+// Validate checks the requested month range, months can't be in the future and FromMonth can't be after ToMonth.
+func (p ExpenseSummaryParams) Validate() error {
+	return validateMonthRange(p.FromMonth, p.ToMonth)
+}
+
+func (p ExpenseSummaryParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newMonthRange(p.Accounts, p.FromMonth, p.ToMonth))
 }
 
 type ExpenseSummary struct {
-	Type         string `json:"type"`
-	ID           string `json:"id"`
-	CoverageDays int    `json:"coverageDays"`
-	BankFees     struct {
-		AvgMonthly    string `json:"avgMonthly"`
-		ChangeHistory []struct {
-			Amount string `json:"amount"`
-			Date   string `json:"date"`
-		} `json:"changeHistory"`
-		Summary string `json:"summary"`
-	} `json:"bankFees"`
-	CashWithdrawals struct {
-		AvgMonthly    string `json:"avgMonthly"`
-		ChangeHistory []struct {
-			Amount string `json:"amount"`
-			Date   string `json:"date"`
-		} `json:"changeHistory"`
-		Summary string `json:"summary"`
-	} `json:"cashWithdrawals"`
-	ExternalTransfers struct {
-		AvgMonthly    string `json:"avgMonthly"`
-		ChangeHistory []struct {
-			Amount string `json:"amount"`
-			Date   string `json:"date"`
-		} `json:"changeHistory"`
-		Summary string `json:"summary"`
-	} `json:"externalTransfers"`
-	FromMonth     string `json:"fromMonth"`
-	LoanInterests struct {
-		AvgMonthly    string `json:"avgMonthly"`
-		ChangeHistory []struct {
-			Amount string `json:"amount"`
-			Date   string `json:"date"`
-		} `json:"changeHistory"`
-		Summary string `json:"summary"`
-	} `json:"loanInterests"`
-	LoanRepayments struct {
-		AvgMonthly    string `json:"avgMonthly"`
-		ChangeHistory []struct {
-			Amount string `json:"amount"`
-			Date   string `json:"date"`
-		} `json:"changeHistory"`
-		Summary string `json:"summary"`
-	} `json:"loanRepayments"`
-	Payments []struct {
-		AvgMonthly      string `json:"avgMonthly"`
-		Division        string `json:"division"`
-		PercentageTotal string `json:"percentageTotal"`
-		SubCategory     []struct {
-			Category struct {
-				ExpenseClass struct {
-					ClassCode     string `json:"classCode"`
-					ClassTitle    string `json:"classTitle"`
-					DivisionCode  string `json:"divisionCode"`
-					DivisionTitle string `json:"divisionTitle"`
-				} `json:"expenseClass"`
-			} `json:"category"`
-			ChangeHistory []struct {
-				Amount string `json:"amount"`
-				Date   string `json:"date"`
-			} `json:"changeHistory"`
-			Summary string `json:"summary"`
-		} `json:"subCategory"`
-	} `json:"payments"`
-	ToMonth string `json:"toMonth"`
-	Links   struct {
-		Accounts []string `json:"accounts"`
-		Self     string   `json:"self"`
-	} `json:"links"`
+	Type              string           `json:"type"`
+	ID                string           `json:"id"`
+	CoverageDays      int              `json:"coverageDays"`
+	BankFees          ExpenseCategory  `json:"bankFees"`
+	CashWithdrawals   ExpenseCategory  `json:"cashWithdrawals"`
+	ExternalTransfers ExpenseCategory  `json:"externalTransfers"`
+	FromMonth         Month            `json:"fromMonth"`
+	LoanInterests     ExpenseCategory  `json:"loanInterests"`
+	LoanRepayments    ExpenseCategory  `json:"loanRepayments"`
+	Payments          []ExpensePayment `json:"payments"`
+	ToMonth           Month            `json:"toMonth"`
+	GeneratedDate     Timestamp        `json:"generatedDate"`
+	Links             SnapshotLinks    `json:"links"`
+}
+
+type ExpenseCategory struct {
+	AvgMonthly    string          `json:"avgMonthly"`
+	ChangeHistory []ChangeHistory `json:"changeHistory"`
+	Summary       string          `json:"summary"`
+}
+
+// ExpensePayment represents the payments of a single ANZSIC division.
+type ExpensePayment struct {
+	AvgMonthly      string               `json:"avgMonthly"`
+	Division        string               `json:"division"`
+	PercentageTotal string               `json:"percentageTotal"`
+	SubCategory     []ExpenseSubCategory `json:"subCategory"`
+}
+
+type ExpenseSubCategory struct {
+	Category      ExpenseClassification `json:"category"`
+	ChangeHistory []ChangeHistory       `json:"changeHistory"`
+	Summary       string                `json:"summary"`
+}
+
+type ExpenseClassification struct {
+	ExpenseClass ExpenseClass `json:"expenseClass"`
+}
+
+type ExpenseClass struct {
+	ClassCode     string `json:"classCode"`
+	ClassTitle    string `json:"classTitle"`
+	DivisionCode  string `json:"divisionCode"`
+	DivisionTitle string `json:"divisionTitle"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) ExpenseSummary(ctx context.Context, userID, snapshotID string) (ExpenseSummary, error) {
+	if err := a.guard(ctx, userID, ConsentEntityTransactions); err != nil {
+		return ExpenseSummary{}, err
+	}
+
 	expenseSummary, err := a.expenseSummary(ctx, userID, snapshotID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return expenseSummary, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -100,8 +89,16 @@ func (a *API) ExpenseSummary(ctx context.Context, userID, snapshotID string) (Ex
 }
 
 func (a *API) CreateExpenseSummary(ctx context.Context, userID string, params ExpenseSummaryParams) (ExpenseSummary, error) {
+	if err := params.Validate(); err != nil {
+		return ExpenseSummary{}, err
+	}
+
+	if err := a.guard(ctx, userID, ConsentEntityTransactions); err != nil {
+		return ExpenseSummary{}, err
+	}
+
 	expenseSummary, err := a.createExpenseSummary(ctx, userID, params)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return expenseSummary, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -113,7 +110,7 @@ func (a *API) CreateExpenseSummary(ctx context.Context, userID string, params Ex
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) expenseSummary(ctx context.Context, userID, snapshotID string) (ExpenseSummary, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "expenses", snapshotID)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "expenses", snapshotID)
 	if err != nil {
 		return ExpenseSummary{}, err
 	}
@@ -128,7 +125,7 @@ func (a *API) expenseSummary(ctx context.Context, userID, snapshotID string) (Ex
 }
 
 func (a *API) createExpenseSummary(ctx context.Context, userID string, params ExpenseSummaryParams) (ExpenseSummary, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "expenses")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "expenses")
 	if err != nil {
 		return ExpenseSummary{}, err
 	}
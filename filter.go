@@ -0,0 +1,16 @@
+package basiq
+
+import (
+	"fmt"
+	"strings"
+)
+
+// filterExpression returns the expression of the filter query parameter comparing the field with the value,
+// e.g. email.eq('jane@example.com'). The filter syntax has no escaping, so values containing quotes or backslashes
+// are rejected instead of breaking the expression.
+func filterExpression(field, operator, value string) (string, error) {
+	if strings.ContainsAny(value, `'\`) {
+		return "", fmt.Errorf("basiq filter value of %s can't contain quotes or backslashes", field)
+	}
+	return fmt.Sprintf("%s.%s('%s')", field, operator, value), nil
+}
@@ -12,28 +12,24 @@ type FloatAccountList struct {
 	Count int            `json:"count"`
 	Size  int            `json:"size"`
 	Data  []FloatAccount `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Links SelfLink       `json:"links"`
 }
 
 type FloatAccount struct {
-	Type             string `json:"type"`
-	ID               string `json:"id"`
-	BankBranchCode   string `json:"bankBranchCode"`
-	AccountNumber    string `json:"accountNumber"`
-	AvailableBalance int    `json:"availableBalance"`
-	Status           string `json:"status"`
-	Links            struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Type             string   `json:"type"`
+	ID               string   `json:"id"`
+	BankBranchCode   string   `json:"bankBranchCode"`
+	AccountNumber    string   `json:"accountNumber"`
+	AvailableBalance int      `json:"availableBalance"`
+	Status           string   `json:"status"`
+	Links            SelfLink `json:"links"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) FloatAccount(ctx context.Context, floatAccountID string) (FloatAccount, error) {
 	floatAccount, err := a.floatAccount(ctx, floatAccountID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return floatAccount, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -44,7 +40,7 @@ func (a *API) FloatAccount(ctx context.Context, floatAccountID string) (FloatAcc
 
 func (a *API) FloatAccounts(ctx context.Context) ([]FloatAccount, error) {
 	floatAccounts, err := a.floatAccounts(ctx)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return floatAccounts, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -56,7 +52,7 @@ func (a *API) FloatAccounts(ctx context.Context) ([]FloatAccount, error) {
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) floatAccount(ctx context.Context, floatAccountID string) (FloatAccount, error) {
-	callURL, err := url.JoinPath(baseURL, "payments", "float-accounts", floatAccountID)
+	callURL, err := url.JoinPath(a.baseURL, "payments", "float-accounts", floatAccountID)
 	if err != nil {
 		return FloatAccount{}, err
 	}
@@ -71,7 +67,7 @@ func (a *API) floatAccount(ctx context.Context, floatAccountID string) (FloatAcc
 }
 
 func (a *API) floatAccounts(ctx context.Context) ([]FloatAccount, error) {
-	callURL, err := url.JoinPath(baseURL, "payments", "float-accounts")
+	callURL, err := url.JoinPath(a.baseURL, "payments", "float-accounts")
 	if err != nil {
 		return nil, err
 	}
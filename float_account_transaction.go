@@ -0,0 +1,132 @@
+package basiq
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// FloatTransactionType represents what has moved the funds of the float account.
+type FloatTransactionType string
+
+const (
+	FloatTransactionTypePayRequest FloatTransactionType = "payrequest"
+	FloatTransactionTypePayout     FloatTransactionType = "payout"
+	FloatTransactionTypeFee        FloatTransactionType = "fee"
+	FloatTransactionTypeDeposit    FloatTransactionType = "deposit"
+	FloatTransactionTypeWithdrawal FloatTransactionType = "withdrawal"
+)
+
+func (t *FloatTransactionType) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*t = FloatTransactionType(v)
+	return err
+}
+
+type FloatAccountTransactionList struct {
+	Type  string                    `json:"type"`
+	Count int                       `json:"count"`
+	Size  int                       `json:"size"`
+	Data  []FloatAccountTransaction `json:"data"`
+	Links PageLinks                 `json:"links"`
+}
+
+// FloatAccountTransaction is a single entry of the float account ledger. Amount is in cents and always positive,
+// Direction tells whether it's a credit or a debit. PayRequestID and PayoutID are set for the entries created
+// by the pay requests and payouts, fees of the payments carry the ID of the payment as well.
+type FloatAccountTransaction struct {
+	Type            string               `json:"type"`
+	ID              string               `json:"id"`
+	FloatAccountID  string               `json:"floatAccountId"`
+	TransactionType FloatTransactionType `json:"transactionType"`
+	Direction       TransactionDirection `json:"direction"`
+	Amount          int                  `json:"amount"`
+	Currency        string               `json:"currency"`
+	Description     string               `json:"description"`
+	PayRequestID    string               `json:"payRequestId,omitempty"`
+	PayoutID        string               `json:"payoutId,omitempty"`
+	Created         Timestamp            `json:"created"`
+	Links           SelfLink             `json:"links"`
+}
+
+// SignedAmount returns the amount in cents, negative for debits.
+func (t FloatAccountTransaction) SignedAmount() int {
+	if t.Direction == TransactionDirectionDebit {
+		return -t.Amount
+	}
+	return t.Amount
+}
+
+// FloatAccountStatement summarises the float account ledger over the period [From, To). All amounts are in cents,
+// Debits are positive.
+type FloatAccountStatement struct {
+	From           time.Time
+	To             time.Time
+	OpeningBalance int
+	ClosingBalance int
+	Credits        int
+	Debits         int
+	Transactions   []FloatAccountTransaction
+}
+
+// NewFloatAccountStatement computes the statement of the period [from, to) from the reference balance of the
+// account. The balance is the ledger balance in cents covering all transactions created before balanceAt, e.g.
+// reconciled with the bank. FloatAccount.AvailableBalance is not a suitable reference, it excludes the pending holds
+// and it's read at a different moment than the transactions. The transactions have to cover the period and
+// everything between the period and balanceAt. Zero to means the period ends at balanceAt. The transactions of
+// the statement keep the given order.
+func NewFloatAccountStatement(balance int, balanceAt time.Time, transactions []FloatAccountTransaction, from, to time.Time) FloatAccountStatement {
+	if to.IsZero() {
+		to = balanceAt
+	}
+
+	statement := FloatAccountStatement{From: from, To: to, ClosingBalance: balance}
+	for _, transaction := range transactions {
+		created := transaction.Created.Time
+
+		// move the reference balance to the end of the period
+		switch {
+		case !created.Before(to) && created.Before(balanceAt):
+			statement.ClosingBalance -= transaction.SignedAmount()
+		case created.Before(to) && !created.Before(balanceAt):
+			statement.ClosingBalance += transaction.SignedAmount()
+		}
+
+		if created.Before(from) || !created.Before(to) {
+			continue
+		}
+		statement.Transactions = append(statement.Transactions, transaction)
+		if transaction.Direction == TransactionDirectionDebit {
+			statement.Debits += transaction.Amount
+		} else {
+			statement.Credits += transaction.Amount
+		}
+	}
+
+	statement.OpeningBalance = statement.ClosingBalance - statement.Credits + statement.Debits
+	return statement
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (a *API) FloatAccountTransactions(ctx context.Context, floatAccountID string) ([]FloatAccountTransaction, error) {
+	transactions, err := a.floatAccountTransactions(ctx, floatAccountID)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return transactions, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return a.floatAccountTransactions(ctx, floatAccountID)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (a *API) floatAccountTransactions(ctx context.Context, floatAccountID string) ([]FloatAccountTransaction, error) {
+	callURL, err := url.JoinPath(a.baseURL, "payments", "float-accounts", floatAccountID, "transactions")
+	if err != nil {
+		return nil, err
+	}
+
+	return listPages[FloatAccountTransaction](ctx, a, callURL)
+}
@@ -0,0 +1,85 @@
+package basiq_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+func TestNewFloatAccountStatement(t *testing.T) {
+	day := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 10, 0, 0, 0, basiq.Sydney())
+	}
+	transaction := func(kind basiq.FloatTransactionType, direction basiq.TransactionDirection, amount int,
+		created time.Time) basiq.FloatAccountTransaction {
+		return basiq.FloatAccountTransaction{
+			TransactionType: kind,
+			Direction:       direction,
+			Amount:          amount,
+			Created:         basiq.NewTimestamp(created),
+		}
+	}
+
+	credit, debit := basiq.TransactionDirectionCredit, basiq.TransactionDirectionDebit
+	transactions := []basiq.FloatAccountTransaction{
+		transaction(basiq.FloatTransactionTypePayRequest, credit, 3000, day(time.February, 20)),
+		transaction(basiq.FloatTransactionTypeDeposit, credit, 5000, day(time.March, 1)),
+		transaction(basiq.FloatTransactionTypePayout, debit, 2000, day(time.March, 10)),
+		transaction(basiq.FloatTransactionTypeFee, debit, 50, day(time.March, 10)),
+		transaction(basiq.FloatTransactionTypePayout, debit, 1000, day(time.March, 20)),
+		transaction(basiq.FloatTransactionTypeWithdrawal, debit, 700, day(time.April, 2)),
+	}
+
+	tests := []struct {
+		name         string
+		balance      int
+		balanceAt    time.Time
+		from, to     time.Time
+		wantOpening  int
+		wantClosing  int
+		wantCredits  int
+		wantDebits   int
+		wantIncluded int
+	}{
+		{
+			name:    "reference after the period",
+			balance: 10000, balanceAt: day(time.March, 31),
+			from: day(time.March, 1), to: day(time.March, 15),
+			wantOpening: 8050, wantClosing: 11000, wantCredits: 5000, wantDebits: 2050, wantIncluded: 3,
+		},
+		{
+			name:    "period ending at the reference",
+			balance: 10000, balanceAt: day(time.March, 31),
+			from:        day(time.March, 1),
+			wantOpening: 8050, wantClosing: 10000, wantCredits: 5000, wantDebits: 3050, wantIncluded: 4,
+		},
+		{
+			name:    "reference before the period end",
+			balance: 8050, balanceAt: day(time.March, 1),
+			from: day(time.March, 1), to: day(time.March, 15),
+			wantOpening: 8050, wantClosing: 11000, wantCredits: 5000, wantDebits: 2050, wantIncluded: 3,
+		},
+		{
+			name:    "period without transactions",
+			balance: 10000, balanceAt: day(time.March, 31),
+			from: day(time.March, 11), to: day(time.March, 15),
+			wantOpening: 11000, wantClosing: 11000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := basiq.NewFloatAccountStatement(tt.balance, tt.balanceAt, transactions, tt.from, tt.to)
+			if got.OpeningBalance != tt.wantOpening || got.ClosingBalance != tt.wantClosing {
+				t.Errorf("balances %d..%d, want %d..%d", got.OpeningBalance, got.ClosingBalance, tt.wantOpening, tt.wantClosing)
+			}
+			if got.Credits != tt.wantCredits || got.Debits != tt.wantDebits {
+				t.Errorf("credits %d, debits %d, want %d and %d", got.Credits, got.Debits, tt.wantCredits, tt.wantDebits)
+			}
+			if len(got.Transactions) != tt.wantIncluded {
+				t.Errorf("%d transactions included, want %d", len(got.Transactions), tt.wantIncluded)
+			}
+		})
+	}
+}
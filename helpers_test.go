@@ -0,0 +1,63 @@
+package basiq_test
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/lukasaron/basiq-go"
+	"github.com/lukasaron/basiq-go/basiqtest"
+)
+
+// callCounter counts the requests sent to the fake server by the method and the path and keeps their queries.
+type callCounter struct {
+	transport http.RoundTripper
+	calls     map[string]int
+	queries   map[string][]string
+	m         sync.Mutex
+}
+
+func (c *callCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.m.Lock()
+	c.calls[req.Method+" "+req.URL.Path]++
+	c.queries[req.Method+" "+req.URL.Path] = append(c.queries[req.Method+" "+req.URL.Path], req.URL.RawQuery)
+	c.m.Unlock()
+	return c.transport.RoundTrip(req)
+}
+
+func (c *callCounter) lastQuery(method, path string) url.Values {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	queries := c.queries[method+" "+path]
+	if len(queries) == 0 {
+		return nil
+	}
+	query, _ := url.ParseQuery(queries[len(queries)-1])
+	return query
+}
+
+func (c *callCounter) count(method, path string) int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.calls[method+" "+path]
+}
+
+// newTestAPI starts the fake server and returns the client calling it, the server is closed with the test.
+func newTestAPI(t *testing.T) (*basiq.API, *basiqtest.Server, *callCounter) {
+	t.Helper()
+
+	srv := basiqtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	counter := &callCounter{transport: srv.Client().Transport, calls: map[string]int{}, queries: map[string][]string{}}
+	config := srv.Config()
+	config.HTTPClient = &http.Client{Transport: counter}
+
+	api, err := basiq.NewAPI(config)
+	if err != nil {
+		t.Fatalf("NewAPI: %v", err)
+	}
+	return api, srv, counter
+}
@@ -14,59 +14,66 @@ type IdentityList struct {
 }
 
 type Identity struct {
-	Type    string `json:"type"`
-	ID      string `json:"id"`
-	Created string `json:"created"`
-	Updated string `json:"updated"`
-	Links   struct {
-		Self string `json:"self"`
-		Job  string `json:"job"`
-	} `json:"links"`
-	Source                string   `json:"source"`
-	FullName              string   `json:"fullName"`
-	FirstName             string   `json:"firstName"`
-	LastName              string   `json:"lastName"`
-	MiddleName            string   `json:"middleName"`
-	Title                 string   `json:"title"`
-	DOB                   string   `json:"DOB"`
-	OccupationCode        string   `json:"occupationCode"`
-	OccupationCodeVersion string   `json:"occupationCodeVersion"`
-	PhoneNumbers          []string `json:"phoneNumbers"`
-	Emails                []string `json:"emails"`
-	PhysicalAddresses     []struct {
-		Type             string `json:"type"`
-		AddressLine1     string `json:"addressLine1"`
-		AddressLine2     string `json:"addressLine2"`
-		AddressLine3     string `json:"addressLine3"`
-		Postcode         string `json:"postcode"`
-		City             string `json:"city"`
-		State            string `json:"state"`
-		Country          string `json:"country"`
-		CountryCode      string `json:"countryCode"`
-		FormattedAddress string `json:"formattedAddress"`
-	} `json:"physicalAddresses"`
-	Organisation struct {
-		AgentFirstName      string `json:"agentFirstName"`
-		AgentLastName       string `json:"agentLastName"`
-		AgentRole           string `json:"agentRole"`
-		BusinessName        string `json:"businessName"`
-		LegalName           string `json:"legalName"`
-		ShortName           string `json:"shortName"`
-		ABN                 string `json:"abn"`
-		ACN                 string `json:"acn"`
-		IsACNCRegistered    bool   `json:"isACNCRegistered"`
-		IndustryCode        string `json:"industryCode"`
-		IndustryCodeVersion string `json:"industryCodeVersion"`
-		OrganisationType    string `json:"organisationType"`
-		RegisteredCountry   string `json:"registeredCountry"`
-	} `json:"organisation"`
+	Type                  string       `json:"type"`
+	ID                    string       `json:"id"`
+	Created               Timestamp    `json:"created"`
+	Updated               Timestamp    `json:"updated"`
+	Links                 JobLinks     `json:"links"`
+	Source                string       `json:"source"`
+	FullName              string       `json:"fullName"`
+	FirstName             string       `json:"firstName"`
+	LastName              string       `json:"lastName"`
+	MiddleName            string       `json:"middleName"`
+	Title                 string       `json:"title"`
+	DOB                   Date         `json:"DOB"`
+	OccupationCode        string       `json:"occupationCode"`
+	OccupationCodeVersion string       `json:"occupationCodeVersion"`
+	PhoneNumbers          []string     `json:"phoneNumbers"`
+	Emails                []string     `json:"emails"`
+	PhysicalAddresses     []Address    `json:"physicalAddresses"`
+	Organisation          Organisation `json:"organisation"`
+}
+
+// Address represents a physical address of a person or an organisation.
+type Address struct {
+	Type             string `json:"type,omitempty"`
+	AddressLine1     string `json:"addressLine1"`
+	AddressLine2     string `json:"addressLine2"`
+	AddressLine3     string `json:"addressLine3"`
+	Postcode         string `json:"postcode"`
+	City             string `json:"city"`
+	State            string `json:"state"`
+	Country          string `json:"country"`
+	CountryCode      string `json:"countryCode"`
+	FormattedAddress string `json:"formattedAddress"`
+}
+
+// Organisation holds the details of the business the identity belongs to.
+type Organisation struct {
+	AgentFirstName      string `json:"agentFirstName"`
+	AgentLastName       string `json:"agentLastName"`
+	AgentRole           string `json:"agentRole"`
+	BusinessName        string `json:"businessName"`
+	LegalName           string `json:"legalName"`
+	ShortName           string `json:"shortName"`
+	ABN                 string `json:"abn"`
+	ACN                 string `json:"acn"`
+	IsACNCRegistered    bool   `json:"isACNCRegistered"`
+	IndustryCode        string `json:"industryCode"`
+	IndustryCodeVersion string `json:"industryCodeVersion"`
+	OrganisationType    string `json:"organisationType"`
+	RegisteredCountry   string `json:"registeredCountry"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) Identity(ctx context.Context, userID, identityID string) (Identity, error) {
+	if err := a.guard(ctx, userID, ConsentEntityIdentity); err != nil {
+		return Identity{}, err
+	}
+
 	identity, err := a.identity(ctx, userID, identityID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return identity, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -76,8 +83,12 @@ func (a *API) Identity(ctx context.Context, userID, identityID string) (Identity
 }
 
 func (a *API) Identities(ctx context.Context, userID string) ([]Identity, error) {
+	if err := a.guard(ctx, userID, ConsentEntityIdentity); err != nil {
+		return nil, err
+	}
+
 	identities, err := a.identities(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return identities, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -89,7 +100,7 @@ func (a *API) Identities(ctx context.Context, userID string) ([]Identity, error)
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) identity(ctx context.Context, userID, identityID string) (Identity, error) {
-	callURl, err := url.JoinPath(baseURL, "users", userID, "identities", identityID)
+	callURl, err := url.JoinPath(a.baseURL, "users", userID, "identities", identityID)
 	if err != nil {
 		return Identity{}, err
 	}
@@ -104,7 +115,7 @@ func (a *API) identity(ctx context.Context, userID, identityID string) (Identity
 }
 
 func (a *API) identities(ctx context.Context, userID string) ([]Identity, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "identities")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "identities")
 	if err != nil {
 		return nil, err
 	}
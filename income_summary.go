@@ -8,94 +8,94 @@ import (
 	"net/url"
 )
 
+// IncomeSummaryParams are encoded by MarshalJSON, unset months are omitted.
 type IncomeSummaryParams struct {
-	Accounts  []string `json:"accounts,omitempty"`
-	FromMonth string   `json:"fromMonth,omitempty"`
-	ToMonth   string   `json:"toMonth,omitempty"`
+	Accounts  []string
+	FromMonth Month
+	ToMonth   Month
+}
+
+// Validate checks the requested month range, months can't be in the future and FromMonth can't be after ToMonth.
+func (p IncomeSummaryParams) Validate() error {
+	return validateMonthRange(p.FromMonth, p.ToMonth)
+}
+
+func (p IncomeSummaryParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newMonthRange(p.Accounts, p.FromMonth, p.ToMonth))
 }
 
 type IncomeSummary struct {
-	Type         string `json:"type"`
-	ID           string `json:"id"`
-	CoverageDays int    `json:"coverageDays"`
-	FromMonth    string `json:"fromMonth"`
-	ToMonth      string `json:"toMonth"`
-	Regular      []struct {
-		Source        string `json:"source"`
-		AgeDays       int    `json:"ageDays"`
-		ChangeHistory []struct {
-			Amount string `json:"amount"`
-			Date   string `json:"date"`
-			Source string `json:"source"`
-		} `json:"changeHistory"`
-		Current struct {
-			Amount   string `json:"amount"`
-			Date     string `json:"date"`
-			NextDate string `json:"nextDate"`
-		} `json:"current"`
-		Frequency    string `json:"frequency"`
-		Irregularity struct {
-			Gaps      []string `json:"gaps"`
-			Stability string   `json:"stability"`
-		} `json:"irregularity"`
-		Previous3Months struct {
-			AmountAvg        string `json:"amountAvg"`
-			AmountAvgMonthly string `json:"amountAvgMonthly"`
-			Variance         string `json:"variance"`
-		} `json:"previous3Months"`
-	} `json:"regular"`
-	Irregular []struct {
-		AgeDays              int    `json:"ageDays"`
-		AmountAvg            string `json:"amountAvg"`
-		AvgMonthlyOccurrence string `json:"avgMonthlyOccurence"`
-		ChangeHistory        []struct {
-			Amount string `json:"amount"`
-			Date   string `json:"date"`
-			Source string `json:"source"`
-		} `json:"changeHistory"`
-		Current struct {
-			Amount string `json:"amount"`
-			Date   string `json:"date"`
-		} `json:"current"`
-		Frequency     string `json:"frequency"`
-		NoOccurrences int    `json:"noOccurrences"`
-		Source        string `json:"source"`
-	} `json:"irregular"`
-	OtherCredit []struct {
-		AgeDays             int    `json:"ageDays"`
-		AmountAvg           string `json:"amountAvg"`
-		AvgMonthlyOccurence string `json:"avgMonthlyOccurence"`
-		ChangeHistory       []struct {
-			Amount string `json:"amount"`
-			Date   string `json:"date"`
-			Source string `json:"source"`
-		} `json:"changeHistory"`
-		Current struct {
-			Amount           string `json:"amount"`
-			Date             string `json:"date"`
-			OtherCreditLabel string `json:"otherCreditLabel"`
-		} `json:"current"`
-		Frequency     string `json:"frequency"`
-		NoOccurrences int    `json:"noOccurrences"`
-		Source        string `json:"source"`
-	} `json:"otherCredit"`
-	Summary struct {
-		IrregularIncomeAvg string      `json:"irregularIncomeAvg"`
-		RegularIncomeAvg   string      `json:"regularIncomeAvg"`
-		RegularIncomeYTD   string      `json:"regularIncomeYTD"`
-		RegularIncomeYear  interface{} `json:"regularIncomeYear"`
-	} `json:"summary"`
-	Links struct {
-		Accounts []string `json:"accounts"`
-		Self     string   `json:"self"`
-	} `json:"links"`
+	Type          string            `json:"type"`
+	ID            string            `json:"id"`
+	CoverageDays  int               `json:"coverageDays"`
+	FromMonth     Month             `json:"fromMonth"`
+	ToMonth       Month             `json:"toMonth"`
+	GeneratedDate Timestamp         `json:"generatedDate"`
+	Regular       []RegularIncome   `json:"regular"`
+	Irregular     []IrregularIncome `json:"irregular"`
+	OtherCredit   []IrregularIncome `json:"otherCredit"`
+	Summary       IncomeTotals      `json:"summary"`
+	Links         SnapshotLinks     `json:"links"`
+}
+
+type RegularIncome struct {
+	Source          string           `json:"source"`
+	AgeDays         int              `json:"ageDays"`
+	ChangeHistory   []ChangeHistory  `json:"changeHistory"`
+	Current         IncomeOccurrence `json:"current"`
+	Frequency       string           `json:"frequency"`
+	Irregularity    Irregularity     `json:"irregularity"`
+	Previous3Months IncomeVariance   `json:"previous3Months"`
+}
+
+// IrregularIncome represents an irregular income or other credit, OtherCreditLabel of the current occurrence
+// is set for other credits only.
+type IrregularIncome struct {
+	AgeDays              int              `json:"ageDays"`
+	AmountAvg            string           `json:"amountAvg"`
+	AvgMonthlyOccurrence string           `json:"avgMonthlyOccurence"`
+	ChangeHistory        []ChangeHistory  `json:"changeHistory"`
+	Current              IncomeOccurrence `json:"current"`
+	Frequency            string           `json:"frequency"`
+	NoOccurrences        int              `json:"noOccurrences"`
+	Source               string           `json:"source"`
+}
+
+// IncomeOccurrence represents the latest occurrence of the income.
+type IncomeOccurrence struct {
+	Amount           string `json:"amount"`
+	Date             Date   `json:"date"`
+	NextDate         Date   `json:"nextDate"`
+	OtherCreditLabel string `json:"otherCreditLabel,omitempty"`
+}
+
+type Irregularity struct {
+	Gaps      []string `json:"gaps"`
+	Stability string   `json:"stability"`
+}
+
+type IncomeVariance struct {
+	AmountAvg        string `json:"amountAvg"`
+	AmountAvgMonthly string `json:"amountAvgMonthly"`
+	Variance         string `json:"variance"`
+}
+
+type IncomeTotals struct {
+	IrregularIncomeAvg string      `json:"irregularIncomeAvg"`
+	RegularIncomeAvg   string      `json:"regularIncomeAvg"`
+	RegularIncomeYTD   string      `json:"regularIncomeYTD"`
+	RegularIncomeYear  interface{} `json:"regularIncomeYear"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) IncomeSummary(ctx context.Context, userID, snapshotID string) (IncomeSummary, error) {
+	if err := a.guard(ctx, userID, ConsentEntityTransactions); err != nil {
+		return IncomeSummary{}, err
+	}
+
 	incomeSummary, err := a.incomeSummary(ctx, userID, snapshotID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return incomeSummary, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -105,8 +105,16 @@ func (a *API) IncomeSummary(ctx context.Context, userID, snapshotID string) (Inc
 }
 
 func (a *API) CreateIncomeSummary(ctx context.Context, userID string, params IncomeSummaryParams) (IncomeSummary, error) {
+	if err := params.Validate(); err != nil {
+		return IncomeSummary{}, err
+	}
+
+	if err := a.guard(ctx, userID, ConsentEntityTransactions); err != nil {
+		return IncomeSummary{}, err
+	}
+
 	incomeSummary, err := a.createIncomeSummary(ctx, userID, params)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return incomeSummary, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -118,7 +126,7 @@ func (a *API) CreateIncomeSummary(ctx context.Context, userID string, params Inc
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) incomeSummary(ctx context.Context, userID, snapshot string) (IncomeSummary, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "income", snapshot)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "income", snapshot)
 	if err != nil {
 		return IncomeSummary{}, err
 	}
@@ -133,7 +141,7 @@ func (a *API) incomeSummary(ctx context.Context, userID, snapshot string) (Incom
 }
 
 func (a *API) createIncomeSummary(ctx context.Context, userID string, params IncomeSummaryParams) (IncomeSummary, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "income")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "income")
 	if err != nil {
 		return IncomeSummary{}, err
 	}
@@ -0,0 +1,204 @@
+package basiq
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// InstitutionStatus represents the service status of the institution.
+type InstitutionStatus string
+
+const (
+	InstitutionStatusOperational      InstitutionStatus = "operational"
+	InstitutionStatusPartialOutage    InstitutionStatus = "partial-outage"
+	InstitutionStatusMajorOutage      InstitutionStatus = "major-outage"
+	InstitutionStatusUnderMaintenance InstitutionStatus = "under-maintenance"
+)
+
+func (s *InstitutionStatus) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*s = InstitutionStatus(v)
+	return err
+}
+
+// IsAvailable returns true when the connections to the institution can be created, possibly with some issues.
+func (s InstitutionStatus) IsAvailable() bool {
+	return s == InstitutionStatusOperational || s == InstitutionStatusPartialOutage
+}
+
+// InstitutionAuthorization represents how the user authorizes the access to the institution.
+type InstitutionAuthorization string
+
+const (
+	InstitutionAuthorizationUser                InstitutionAuthorization = "user"
+	InstitutionAuthorizationUserMFA             InstitutionAuthorization = "user-mfa"
+	InstitutionAuthorizationUserMFAIntermittent InstitutionAuthorization = "user-mfa-intermittent"
+	InstitutionAuthorizationOther               InstitutionAuthorization = "other"
+)
+
+func (a *InstitutionAuthorization) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*a = InstitutionAuthorization(v)
+	return err
+}
+
+// InstitutionParams filters the institutions, empty fields are not used. The filters are sent to the API,
+// e.g. institution.tier.eq('1'), and applied again on the returned institutions as the API ignores the fields
+// it can't filter by. Country is compared case-insensitively on the client.
+type InstitutionParams struct {
+	Country       string
+	Tier          ConnectorTier
+	Authorization InstitutionAuthorization
+	Status        InstitutionStatus
+}
+
+func (p InstitutionParams) filter() (string, error) {
+	var filters []string
+	for _, f := range []struct{ field, value string }{
+		{"institution.country", p.Country},
+		{"institution.tier", string(p.Tier)},
+		{"institution.authorization", string(p.Authorization)},
+		{"institution.status", string(p.Status)},
+	} {
+		if f.value == "" {
+			continue
+		}
+		expression, err := filterExpression(f.field, "eq", f.value)
+		if err != nil {
+			return "", err
+		}
+		filters = append(filters, expression)
+	}
+	return strings.Join(filters, ","), nil
+}
+
+func (p InstitutionParams) matches(institution Institution) bool {
+	switch {
+	case p.Country != "" && !strings.EqualFold(p.Country, institution.Country):
+		return false
+	case p.Tier != "" && p.Tier != institution.Tier:
+		return false
+	case p.Authorization != "" && p.Authorization != institution.Authorization:
+		return false
+	case p.Status != "" && p.Status != institution.Status:
+		return false
+	default:
+		return true
+	}
+}
+
+type InstitutionList struct {
+	Type       string        `json:"type"`
+	TotalCount int           `json:"totalCount"`
+	Data       []Institution `json:"data"`
+	Links      SelfLink      `json:"links"`
+}
+
+// Institution represents a bank or other financial institution the users can connect to. Its ID is the one found
+// on accounts, transactions and connections.
+type Institution struct {
+	Type                 string                   `json:"type"`
+	ID                   string                   `json:"id"`
+	Name                 string                   `json:"name"`
+	ShortName            string                   `json:"shortName"`
+	InstitutionType      string                   `json:"institutionType"`
+	Country              string                   `json:"country"`
+	ServiceName          string                   `json:"serviceName"`
+	ServiceType          string                   `json:"serviceType"`
+	LoginIdCaption       string                   `json:"loginIdCaption"`
+	PasswordCaption      string                   `json:"passwordCaption"`
+	ForgottenPasswordUrl string                   `json:"forgottenPasswordUrl"`
+	Tier                 ConnectorTier            `json:"tier"`
+	Authorization        InstitutionAuthorization `json:"authorization"`
+	Stage                ConnectorStage           `json:"stage"`
+	Status               InstitutionStatus        `json:"status"`
+	Logo                 Logo                     `json:"logo"`
+	Stats                ConnectorStats           `json:"stats"`
+	Links                SelfLink                 `json:"links"`
+}
+
+// IndexInstitutions maps the institutions by their IDs, so the institutions of accounts, transactions and
+// connections can be resolved without further calls.
+func IndexInstitutions(institutions []Institution) map[string]Institution {
+	index := make(map[string]Institution, len(institutions))
+	for _, institution := range institutions {
+		index[institution.ID] = institution
+	}
+	return index
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (a *API) Institution(ctx context.Context, institutionID string) (Institution, error) {
+	institution, err := a.institution(ctx, institutionID)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return institution, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return Institution{}, err
+	}
+	return a.institution(ctx, institutionID)
+}
+
+func (a *API) Institutions(ctx context.Context, params InstitutionParams) ([]Institution, error) {
+	institutions, err := a.institutions(ctx, params)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return institutions, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return a.institutions(ctx, params)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (a *API) institution(ctx context.Context, institutionID string) (Institution, error) {
+	callURL, err := url.JoinPath(a.baseURL, "institutions", institutionID)
+	if err != nil {
+		return Institution{}, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
+	if err != nil {
+		return Institution{}, err
+	}
+
+	var institution Institution
+	return institution, json.Unmarshal(data, &institution)
+}
+
+func (a *API) institutions(ctx context.Context, params InstitutionParams) ([]Institution, error) {
+	filter, err := params.filter()
+	if err != nil {
+		return nil, err
+	}
+	callURL, err := url.JoinPath(a.baseURL, "institutions")
+	if err != nil {
+		return nil, err
+	}
+	if filter != "" {
+		callURL += "?" + url.Values{"filter": {filter}}.Encode()
+	}
+
+	data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list InstitutionList
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	institutions := make([]Institution, 0, len(list.Data))
+	for _, institution := range list.Data {
+		if params.matches(institution) {
+			institutions = append(institutions, institution)
+		}
+	}
+	return institutions, nil
+}
@@ -0,0 +1,57 @@
+package basiq_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+func TestInstitutionsSendsFilter(t *testing.T) {
+	api, srv, counter := newTestAPI(t)
+	srv.AddInstitution(basiq.Institution{ID: "AU00000", Country: "Australia", Tier: "1", Status: basiq.InstitutionStatusOperational})
+	srv.AddInstitution(basiq.Institution{ID: "AU00001", Country: "Australia", Tier: "2", Authorization: basiq.InstitutionAuthorizationUser, Status: basiq.InstitutionStatusOperational})
+
+	tests := []struct {
+		name       string
+		params     basiq.InstitutionParams
+		wantFilter string
+		wantCount  int
+	}{
+		{"no filter", basiq.InstitutionParams{}, "", 2},
+		{"tier", basiq.InstitutionParams{Tier: "1"}, "institution.tier.eq('1')", 1},
+		{
+			name:       "all fields",
+			params:     basiq.InstitutionParams{Country: "Australia", Tier: "2", Authorization: basiq.InstitutionAuthorizationUser, Status: basiq.InstitutionStatusOperational},
+			wantFilter: "institution.country.eq('Australia'),institution.tier.eq('2'),institution.authorization.eq('user'),institution.status.eq('operational')",
+			wantCount:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			institutions, err := api.Institutions(context.Background(), tt.params)
+			if err != nil {
+				t.Fatalf("Institutions: %v", err)
+			}
+			if filter := counter.lastQuery(http.MethodGet, "/institutions").Get("filter"); filter != tt.wantFilter {
+				t.Errorf("filter = %q, want %q", filter, tt.wantFilter)
+			}
+			if len(institutions) != tt.wantCount {
+				t.Errorf("Institutions returned %d institutions, want %d", len(institutions), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestInstitutionsRejectsQuotedValues(t *testing.T) {
+	api, _, counter := newTestAPI(t)
+
+	if _, err := api.Institutions(context.Background(), basiq.InstitutionParams{Country: "Australia')"}); err == nil {
+		t.Fatal("Institutions accepted the quoted country")
+	}
+	if n := counter.count(http.MethodGet, "/institutions"); n != 0 {
+		t.Errorf("GET /institutions sent %d times, want none", n)
+	}
+}
@@ -5,32 +5,147 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
+// JobStepStatus represents the progress of a single job step.
+type JobStepStatus string
+
+const (
+	JobStepStatusPending    JobStepStatus = "pending"
+	JobStepStatusInProgress JobStepStatus = "in-progress"
+	JobStepStatusSuccess    JobStepStatus = "success"
+	JobStepStatusFailed     JobStepStatus = "failed"
+)
+
+// IsTerminal returns true when the step has finished, either successfully or not.
+func (s JobStepStatus) IsTerminal() bool {
+	return s == JobStepStatusSuccess || s == JobStepStatusFailed
+}
+
+func (s *JobStepStatus) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*s = JobStepStatus(v)
+	return err
+}
+
 type Job struct {
-	Type    string `json:"type"`
-	ID      string `json:"id"`
-	Created string `json:"created"`
-	Updated string `json:"updated"`
-	Steps   []struct {
-		Title  string `json:"title"`
-		Status string `json:"status"`
-		Result struct {
-			Type string `json:"type"`
-			URL  string `json:"url"`
-		} `json:"result"`
-	} `json:"steps"`
-	Links struct {
-		Self   string `json:"self"`
-		Source string `json:"source"`
-	} `json:"links"`
+	Type    string      `json:"type"`
+	ID      string      `json:"id"`
+	Created Timestamp   `json:"created"`
+	Updated Timestamp   `json:"updated"`
+	Steps   []JobStep   `json:"steps"`
+	Links   SourceLinks `json:"links"`
+}
+
+// Titles of the steps of the connection jobs.
+const (
+	JobStepVerifyCredentials    = "verify-credentials"
+	JobStepRetrieveAccounts     = "retrieve-accounts"
+	JobStepRetrieveTransactions = "retrieve-transactions"
+	JobStepRetrieveMetadata     = "retrieve-metadata"
+)
+
+// Step returns the step with the given title.
+func (j Job) Step(title string) (JobStep, bool) {
+	for _, step := range j.Steps {
+		if step.Title == title {
+			return step, true
+		}
+	}
+	return JobStep{}, false
+}
+
+// FailedStep returns the first failed step of the job.
+func (j Job) FailedStep() (JobStep, bool) {
+	for _, step := range j.Steps {
+		if step.Status == JobStepStatusFailed {
+			return step, true
+		}
+	}
+	return JobStep{}, false
+}
+
+// MFAStep returns the step waiting for the MFA response of the user.
+func (j Job) MFAStep() (JobStep, bool) {
+	for _, step := range j.Steps {
+		if !step.Status.IsTerminal() && step.Result.RequiresMFA() {
+			return step, true
+		}
+	}
+	return JobStep{}, false
+}
+
+// IsSuccessful returns true when all steps of the job have succeeded.
+func (j Job) IsSuccessful() bool {
+	if len(j.Steps) == 0 {
+		return false
+	}
+	for _, step := range j.Steps {
+		if step.Status != JobStepStatusSuccess {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFinished returns true when the job has succeeded or any of its steps has failed.
+func (j Job) IsFinished() bool {
+	_, failed := j.FailedStep()
+	return failed || j.IsSuccessful()
+}
+
+// linkedID returns the ID of the last resource of the collection (e.g. reports) linked by the results of the job
+// steps or by the job itself.
+func (j Job) linkedID(collection string) (string, bool) {
+	links := []string{j.Links.Source}
+	for _, step := range j.Steps {
+		links = append(links, step.Result.URL)
+	}
+
+	for i := len(links) - 1; i >= 0; i-- {
+		ref, err := url.Parse(links[i])
+		if err != nil {
+			continue
+		}
+		segments := strings.Split(strings.Trim(ref.Path, "/"), "/")
+		if len(segments) >= 2 && segments[len(segments)-2] == collection {
+			return segments[len(segments)-1], true
+		}
+	}
+	return "", false
+}
+
+// JobStep represents a single step of the job (e.g. verify-credentials, retrieve-accounts).
+type JobStep struct {
+	Title  string        `json:"title"`
+	Status JobStepStatus `json:"status"`
+	Result JobStepResult `json:"result"`
+}
+
+// JobStepResult holds the link to the resource created by a successful step, the failure details or the MFA
+// challenge when the institution requires additional authentication.
+type JobStepResult struct {
+	Type       string    `json:"type"`
+	URL        string    `json:"url"`
+	Code       string    `json:"code"`
+	Title      string    `json:"title"`
+	Details    string    `json:"details"`
+	MFAType    MFAType   `json:"mfa-type"`
+	MFAPrompt  string    `json:"mfa-prompt"`
+	MFAExpires Timestamp `json:"mfa-expires"`
+}
+
+// RequiresMFA returns true when the step waits for the MFA response of the user.
+func (r JobStepResult) RequiresMFA() bool {
+	return r.Type == "mfa" || r.Code == "mfa-required"
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) Job(ctx context.Context, jobID string) (Job, error) {
 	job, err := a.job(ctx, jobID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return job, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -42,7 +157,7 @@ func (a *API) Job(ctx context.Context, jobID string) (Job, error) {
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) job(ctx context.Context, jobID string) (Job, error) {
-	callURl, err := url.JoinPath(baseURL, "jobs", jobID)
+	callURl, err := url.JoinPath(a.baseURL, "jobs", jobID)
 	if err != nil {
 		return Job{}, err
 	}
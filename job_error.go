@@ -0,0 +1,216 @@
+package basiq
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors of the known job failures, use errors.Is to check the cause of a *JobError.
+var (
+	ErrInvalidCredentials      = errors.New("basiq invalid credentials")
+	ErrAccountLocked           = errors.New("basiq account locked")
+	ErrUserActionRequired      = errors.New("basiq user action required")
+	ErrMFAFailed               = errors.New("basiq MFA failed")
+	ErrInstitutionUnavailable  = errors.New("basiq institution unavailable")
+	ErrInstitutionMaintenance  = errors.New("basiq institution maintenance")
+	ErrInstitutionNotSupported = errors.New("basiq institution not supported")
+	ErrConsentRevoked          = errors.New("basiq consent revoked")
+	ErrConsentExpired          = errors.New("basiq consent expired")
+	ErrJobFailed               = errors.New("basiq job failed")
+)
+
+// JobFailureClass tells how the failure of a job can be resolved.
+type JobFailureClass int
+
+const (
+	// JobFailureUnknown is used for failure codes the client doesn't know.
+	JobFailureUnknown JobFailureClass = iota
+	// JobFailureRetryable failures are temporary, the job can be retried later without any user interaction.
+	JobFailureRetryable
+	// JobFailureUserAction failures require the user to act (e.g. fix the credentials) before retrying.
+	JobFailureUserAction
+	// JobFailurePermanent failures won't be resolved by retrying.
+	JobFailurePermanent
+)
+
+func (c JobFailureClass) String() string {
+	switch c {
+	case JobFailureRetryable:
+		return "retryable"
+	case JobFailureUserAction:
+		return "user-action-required"
+	case JobFailurePermanent:
+		return "permanent"
+	default:
+		return "unknown"
+	}
+}
+
+type jobFailure struct {
+	err         error
+	class       JobFailureClass
+	remediation string
+}
+
+var (
+	defaultRemediation = "Something went wrong while connecting to your bank. Please try again later."
+
+	jobFailures = map[string]jobFailure{
+		"invalid-credentials": {
+			err:         ErrInvalidCredentials,
+			class:       JobFailureUserAction,
+			remediation: "The login details are incorrect. Please check them and try again.",
+		},
+		"account-locked": {
+			err:         ErrAccountLocked,
+			class:       JobFailureUserAction,
+			remediation: "Your online banking access is locked. Please unlock it with your bank and try again.",
+		},
+		"user-action-required": {
+			err:         ErrUserActionRequired,
+			class:       JobFailureUserAction,
+			remediation: "Your bank needs you to complete an action in online banking (e.g. accept new terms). Please log in to your bank and try again.",
+		},
+		"mfa-failed": {
+			err:         ErrMFAFailed,
+			class:       JobFailureUserAction,
+			remediation: "The verification code was incorrect or has expired. Please try again.",
+		},
+		"institution-not-available": {
+			err:         ErrInstitutionUnavailable,
+			class:       JobFailureRetryable,
+			remediation: "Your bank is currently unavailable. Please try again later.",
+		},
+		"maintenance": {
+			err:         ErrInstitutionMaintenance,
+			class:       JobFailureRetryable,
+			remediation: "Your bank is undergoing maintenance. Please try again later.",
+		},
+		"institution-not-supported": {
+			err:         ErrInstitutionNotSupported,
+			class:       JobFailurePermanent,
+			remediation: "Your bank is not supported at the moment. Please choose another bank.",
+		},
+		"consent-revoked": {
+			err:         ErrConsentRevoked,
+			class:       JobFailureUserAction,
+			remediation: "Access to your bank data has been revoked. Please connect your bank again.",
+		},
+		"consent-expired": {
+			err:         ErrConsentExpired,
+			class:       JobFailureUserAction,
+			remediation: "Access to your bank data has expired. Please connect your bank again.",
+		},
+	}
+
+	// jobFailureAliases maps the alternative codes used by the connectors to the known failures.
+	jobFailureAliases = map[string]string{
+		"mfa-invalid":             "mfa-failed",
+		"mfa-timeout":             "mfa-failed",
+		"institution-unavailable": "institution-not-available",
+		"institution-disabled":    "institution-not-available",
+		"system-unavailable":      "institution-not-available",
+		"service-unavailable":     "institution-not-available",
+		"connector-error":         "institution-not-available",
+		"institution-maintenance": "maintenance",
+		"institution-not-found":   "institution-not-supported",
+	}
+)
+
+// JobError is returned when a step of a job has failed. It carries the failure details from the step result.
+// Known failure codes can be checked with errors.Is against the sentinel errors (e.g. ErrInvalidCredentials).
+type JobError struct {
+	JobID   string
+	Step    string
+	Code    string
+	Title   string
+	Details string
+}
+
+// NewJobError creates the error from the failed step of the job.
+func NewJobError(jobID string, step JobStep) *JobError {
+	return &JobError{
+		JobID:   jobID,
+		Step:    step.Title,
+		Code:    step.Result.Code,
+		Title:   step.Result.Title,
+		Details: step.Result.Details,
+	}
+}
+
+// Err returns the *JobError of the first failed step, nil when no step has failed.
+func (j Job) Err() error {
+	if step, failed := j.FailedStep(); failed {
+		return NewJobError(j.ID, step)
+	}
+	return nil
+}
+
+// Err returns the *JobError of the first failed step, nil when no step has failed.
+func (j UserJob) Err() error {
+	for _, step := range j.Steps {
+		if step.Status == JobStepStatusFailed {
+			return NewJobError(j.ID, step)
+		}
+	}
+	return nil
+}
+
+// IsRetryableJobErr returns true when the error is a temporary job failure.
+func IsRetryableJobErr(err error) bool {
+	var e *JobError
+	return errors.As(err, &e) && e.Class() == JobFailureRetryable
+}
+
+// IsUserActionRequiredErr returns true when the error is a job failure the user has to resolve.
+func IsUserActionRequiredErr(err error) bool {
+	var e *JobError
+	return errors.As(err, &e) && e.Class() == JobFailureUserAction
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (e *JobError) Error() string {
+	return fmt.Sprintf("job %s failed at %s: %s: %s", e.JobID, e.Step, e.Title, e.Details)
+}
+
+// Unwrap returns the sentinel error of the failure code, ErrJobFailed for unknown codes.
+func (e *JobError) Unwrap() error {
+	if f, ok := e.failure(); ok {
+		return f.err
+	}
+	return ErrJobFailed
+}
+
+// Class returns how the failure can be resolved.
+func (e *JobError) Class() JobFailureClass {
+	f, _ := e.failure()
+	return f.class
+}
+
+// Retryable returns true when the job can be retried later without any user interaction.
+func (e *JobError) Retryable() bool {
+	return e.Class() == JobFailureRetryable
+}
+
+// UserActionRequired returns true when the user has to act before the job is retried.
+func (e *JobError) UserActionRequired() bool {
+	return e.Class() == JobFailureUserAction
+}
+
+// Remediation returns a message describing the next steps which can be shown to the end user.
+func (e *JobError) Remediation() string {
+	if f, ok := e.failure(); ok {
+		return f.remediation
+	}
+	return defaultRemediation
+}
+
+func (e *JobError) failure() (jobFailure, bool) {
+	code := e.Code
+	if alias, ok := jobFailureAliases[code]; ok {
+		code = alias
+	}
+	f, ok := jobFailures[code]
+	return f, ok
+}
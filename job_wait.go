@@ -0,0 +1,145 @@
+package basiq
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	defaultJobInterval    = time.Second
+	defaultJobMaxInterval = 10 * time.Second
+)
+
+// JobWaitOptions configures how WaitForJob polls the job. Zero values fall back to the defaults.
+type JobWaitOptions struct {
+	// Interval is the initial pause between two polls, it's reset to this value whenever the job progresses.
+	Interval time.Duration
+	// MaxInterval caps the backoff of the pause between two polls.
+	MaxInterval time.Duration
+	// OnStep is called for every step transition.
+	OnStep func(JobStepEvent)
+	// Steps receives every step transition, the channel is not closed by WaitForJob.
+	Steps chan<- JobStepEvent
+	// MFA answers the challenges of institutions requiring multi-factor authentication. Without the handler
+	// the job waits until the challenge is answered in another way or it expires.
+	MFA MFAHandler
+	// MFAAttempts limits how many times the MFA handler is asked for a challenge answer, defaults to 3.
+	MFAAttempts int
+}
+
+// JobStepEvent represents a change of the status of a single job step.
+type JobStepEvent struct {
+	JobID    string
+	Step     JobStep
+	Previous JobStepStatus
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// WaitForJob polls the job until all of its steps succeed, any step fails or the context is done. Step transitions
+// are reported via the options. When a step fails the returned error is a *JobError built from the step result.
+// MFA challenges are answered with the MFA handler of the options, when it's set.
+func (a *API) WaitForJob(ctx context.Context, jobID string, opts JobWaitOptions) (Job, error) {
+	opts = opts.withDefaults()
+	if opts.MFA == nil {
+		return a.waitForJob(ctx, jobID, opts, nil)
+	}
+
+	responder := &mfaResponder{api: a, handler: opts.MFA, maxAttempts: opts.MFAAttempts}
+	return a.waitForJob(ctx, jobID, opts, func(job Job) error {
+		return responder.inspect(ctx, job)
+	})
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// waitForJob runs the polling loop, the optional inspect function is called with every fetched job and can stop
+// the loop by returning an error.
+func (a *API) waitForJob(ctx context.Context, jobID string, opts JobWaitOptions, inspect func(Job) error) (Job, error) {
+	opts = opts.withDefaults()
+
+	statuses := map[string]JobStepStatus{}
+	interval := opts.Interval
+	for {
+		job, err := a.Job(ctx, jobID)
+		if err != nil {
+			return job, err
+		}
+
+		progressed, err := opts.report(ctx, job, statuses)
+		if err != nil {
+			return job, err
+		}
+
+		if step, failed := job.FailedStep(); failed {
+			return job, NewJobError(jobID, step)
+		}
+		if job.IsSuccessful() {
+			return job, nil
+		}
+		if inspect != nil {
+			if err = inspect(job); err != nil {
+				return job, err
+			}
+		}
+
+		if progressed {
+			interval = opts.Interval
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return job, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = interval * 3 / 2
+		if interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
+
+func (o JobWaitOptions) withDefaults() JobWaitOptions {
+	if o.Interval <= 0 {
+		o.Interval = defaultJobInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = defaultJobMaxInterval
+	}
+	if o.MFAAttempts <= 0 {
+		o.MFAAttempts = defaultMFAAttempts
+	}
+	if o.MaxInterval < o.Interval {
+		o.MaxInterval = o.Interval
+	}
+	return o
+}
+
+// report delivers all step transitions since the last poll and returns true when there was any.
+func (o JobWaitOptions) report(ctx context.Context, job Job, statuses map[string]JobStepStatus) (bool, error) {
+	var progressed bool
+	for _, step := range job.Steps {
+		previous, seen := statuses[step.Title]
+		if seen && previous == step.Status {
+			continue
+		}
+		statuses[step.Title] = step.Status
+		progressed = true
+
+		event := JobStepEvent{JobID: job.ID, Step: step, Previous: previous}
+		if o.OnStep != nil {
+			o.OnStep(event)
+		}
+		if o.Steps != nil {
+			select {
+			case o.Steps <- event:
+			case <-ctx.Done():
+				return progressed, ctx.Err()
+			}
+		}
+	}
+	return progressed, nil
+}
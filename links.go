@@ -0,0 +1,38 @@
+package basiq
+
+// SelfLink holds the link of the resource itself.
+type SelfLink struct {
+	Self string `json:"self"`
+}
+
+// PageLinks holds the links of a paginated list, Next is empty on the last page.
+type PageLinks struct {
+	Self string `json:"self"`
+	Next string `json:"next"`
+}
+
+// SourceLinks holds the links of a resource and the resource it originates from.
+type SourceLinks struct {
+	Self   string `json:"self"`
+	Source string `json:"source"`
+}
+
+// JobLinks holds the links of a resource and the job that has created it.
+type JobLinks struct {
+	Self string `json:"self"`
+	Job  string `json:"job"`
+}
+
+// ResourceRef is a reference to another resource, typically an account or a connection of a user.
+type ResourceRef struct {
+	Type  string   `json:"type"`
+	ID    string   `json:"id"`
+	Links SelfLink `json:"links"`
+}
+
+// ResourceRefList is a counted list of references to other resources.
+type ResourceRefList struct {
+	Type  string        `json:"type"`
+	Count int           `json:"count"`
+	Data  []ResourceRef `json:"data"`
+}
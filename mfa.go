@@ -4,27 +4,62 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
 )
 
+var (
+	// ErrMFAAttemptsExceeded is returned when the MFA challenge hasn't been answered correctly within the limit.
+	ErrMFAAttemptsExceeded = errors.New("basiq MFA attempts exceeded")
+)
+
+var defaultMFAAttempts = 3
+
+// MFAType represents the kind of the challenge the institution requires.
+type MFAType string
+
+const (
+	MFATypeToken            MFAType = "token"
+	MFATypeOneTimePassword  MFAType = "one-time-password"
+	MFATypeSecurityQuestion MFAType = "security-question"
+)
+
+func (t *MFAType) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*t = MFAType(v)
+	return err
+}
+
+// MFAChallenge describes the challenge the user has to answer to let the job continue.
+type MFAChallenge struct {
+	JobID   string
+	Step    string
+	Type    MFAType
+	Prompt  string
+	Expires Timestamp
+	// Attempt starts at 1 and is increased every time the previous answer has been rejected.
+	Attempt int
+}
+
+// MFAHandler obtains the answer for the challenge, typically by prompting the user.
+type MFAHandler func(ctx context.Context, challenge MFAChallenge) ([]string, error)
+
 type MFAParams struct {
 	MFAResponse []string `json:"mfa-response"`
 }
 
 type MFA struct {
-	Type  string `json:"type"`
-	ID    string `json:"id"`
-	Links struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Type  string   `json:"type"`
+	ID    string   `json:"id"`
+	Links SelfLink `json:"links"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) CreateMFAResponse(ctx context.Context, jobID string, params MFAParams) (MFA, error) {
 	mfa, err := a.createMFA(ctx, jobID, params)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return mfa, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -36,7 +71,7 @@ func (a *API) CreateMFAResponse(ctx context.Context, jobID string, params MFAPar
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) createMFA(ctx context.Context, jobID string, params MFAParams) (MFA, error) {
-	callURL, err := url.JoinPath(baseURL, "jobs", jobID, "mfa")
+	callURL, err := url.JoinPath(a.baseURL, "jobs", jobID, "mfa")
 	if err != nil {
 		return MFA{}, err
 	}
@@ -54,3 +89,45 @@ func (a *API) createMFA(ctx context.Context, jobID string, params MFAParams) (MF
 	var mfa MFA
 	return mfa, json.Unmarshal(data, &mfa)
 }
+
+// mfaResponder answers the MFA challenges of a single job while it's being polled.
+type mfaResponder struct {
+	api         *API
+	handler     MFAHandler
+	maxAttempts int
+	attempts    int
+	answeredAt  Timestamp
+}
+
+func (r *mfaResponder) inspect(ctx context.Context, job Job) error {
+	step, ok := job.MFAStep()
+	if !ok {
+		return nil
+	}
+
+	// the answer is still being processed when the job hasn't been updated since it was sent,
+	// a newer challenge means the previous answer has been rejected
+	if r.attempts > 0 && !job.Updated.After(r.answeredAt.Time) {
+		return nil
+	}
+	if r.attempts >= r.maxAttempts {
+		return ErrMFAAttemptsExceeded
+	}
+	r.attempts++
+
+	answer, err := r.handler(ctx, MFAChallenge{
+		JobID:   job.ID,
+		Step:    step.Title,
+		Type:    step.Result.MFAType,
+		Prompt:  step.Result.MFAPrompt,
+		Expires: step.Result.MFAExpires,
+		Attempt: r.attempts,
+	})
+	if err != nil {
+		return err
+	}
+
+	r.answeredAt = job.Updated
+	_, err = r.api.CreateMFAResponse(ctx, job.ID, MFAParams{MFAResponse: answer})
+	return err
+}
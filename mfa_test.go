@@ -0,0 +1,45 @@
+package basiq_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+func TestWaitForJobAnswersMFA(t *testing.T) {
+	api, srv, counter := newTestAPI(t)
+
+	job := srv.SetJob(basiq.Job{Steps: []basiq.JobStep{
+		{Title: basiq.JobStepVerifyCredentials, Status: basiq.JobStepStatusInProgress, Result: basiq.JobStepResult{
+			Type:      "mfa",
+			MFAType:   basiq.MFATypeOneTimePassword,
+			MFAPrompt: "Enter the code",
+		}},
+	}})
+
+	var challenges []basiq.MFAChallenge
+	got, err := api.WaitForJob(context.Background(), job.ID, basiq.JobWaitOptions{
+		Interval: time.Millisecond,
+		MFA: func(_ context.Context, challenge basiq.MFAChallenge) ([]string, error) {
+			challenges = append(challenges, challenge)
+			return []string{"123456"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("WaitForJob: %v", err)
+	}
+	if !got.IsSuccessful() {
+		t.Errorf("job %s hasn't succeeded: %+v", got.ID, got.Steps)
+	}
+	if len(challenges) != 1 {
+		t.Fatalf("handler called %d times, want 1", len(challenges))
+	}
+	if c := challenges[0]; c.Type != basiq.MFATypeOneTimePassword || c.Prompt != "Enter the code" || c.Attempt != 1 {
+		t.Errorf("unexpected challenge %+v", c)
+	}
+	if n := counter.count("POST", "/jobs/"+job.ID+"/mfa"); n != 1 {
+		t.Errorf("MFA answer posted %d times, want 1", n)
+	}
+}
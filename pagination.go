@@ -0,0 +1,54 @@
+package basiq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// pageCursor follows the next links of a paginated list. It remembers all loaded pages, so the links forming
+// a cycle end with an error instead of loading the same pages forever.
+type pageCursor struct {
+	callURL string
+	visited map[string]bool
+}
+
+// advance moves the cursor from the loaded page to the next one, the cursor is exhausted when next is empty.
+func (c *pageCursor) advance(next string) error {
+	if c.visited == nil {
+		c.visited = map[string]bool{}
+	}
+	loaded := c.callURL
+	c.visited[loaded] = true
+	if next != "" && c.visited[next] {
+		c.callURL = ""
+		return fmt.Errorf("basiq page %s links the already loaded page %s as the next one", loaded, next)
+	}
+	c.callURL = next
+	return nil
+}
+
+// listPages loads all pages of the list starting at callURL and returns their items in the order of the pages.
+func listPages[T any](ctx context.Context, a *API, callURL string) ([]T, error) {
+	var items []T
+	cursor := pageCursor{callURL: callURL}
+	for cursor.callURL != "" {
+		data, err := a.makeCall(ctx, http.MethodGet, cursor.callURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		var list struct {
+			Data  []T       `json:"data"`
+			Links PageLinks `json:"links"`
+		}
+		if err = json.Unmarshal(data, &list); err != nil {
+			return nil, err
+		}
+		items = append(items, list.Data...)
+		if err = cursor.advance(list.Links.Next); err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,111 @@
+package basiq_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/lukasaron/basiq-go"
+	"github.com/lukasaron/basiq-go/basiqtest"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestPaginationStopsAtLinkCycle(t *testing.T) {
+	tests := []struct {
+		path string
+		list func(api *basiq.API) (int, error)
+	}{
+		{"/users", func(api *basiq.API) (int, error) {
+			var n int
+			it := api.Users(context.Background())
+			for it.Next() && n < 10 {
+				n++
+			}
+			return n, it.Err()
+		}},
+		{"/users/user-1/transactions", func(api *basiq.API) (int, error) {
+			transactions, err := api.Transactions(context.Background(), "user-1")
+			return len(transactions), err
+		}},
+		{"/events", func(api *basiq.API) (int, error) {
+			events, err := api.Events(context.Background())
+			return len(events), err
+		}},
+		{"/payments/payrequests", func(api *basiq.API) (int, error) {
+			payRequests, err := api.PayRequests(context.Background())
+			return len(payRequests), err
+		}},
+		{"/payments/payouts", func(api *basiq.API) (int, error) {
+			payouts, err := api.Payouts(context.Background())
+			return len(payouts), err
+		}},
+		{"/reports", func(api *basiq.API) (int, error) {
+			reports, err := api.Reports(context.Background())
+			return len(reports), err
+		}},
+		{"/payments/float-accounts/float-1/transactions", func(api *basiq.API) (int, error) {
+			transactions, err := api.FloatAccountTransactions(context.Background(), "float-1")
+			return len(transactions), err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			api, pages := newCyclingAPI(t, tt.path)
+
+			_, err := tt.list(api)
+			if err == nil {
+				t.Error("the cycle of the page links hasn't been reported")
+			}
+			if *pages != 2 {
+				t.Errorf("%d pages loaded, want 2", *pages)
+			}
+		})
+	}
+}
+
+// newCyclingAPI returns the client whose calls of the path get two pages linking each other as the next page,
+// all other calls are served by the fake server.
+func newCyclingAPI(t *testing.T, path string) (*basiq.API, *int) {
+	t.Helper()
+
+	srv := basiqtest.NewServer()
+	t.Cleanup(srv.Close)
+
+	pages := 0
+	transport := srv.Client().Transport
+	config := srv.Config()
+	config.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != path {
+			return transport.RoundTrip(req)
+		}
+		pages++
+		first := srv.URL + path
+		next := first + "?page=2"
+		if req.URL.Query().Get("page") == "2" {
+			next = first
+		}
+		body := fmt.Sprintf(`{"type":"list","data":[{"type":"item","id":"item-%d"}],"links":{"self":%q,"next":%q}}`,
+			pages, req.URL.String(), next)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	})}
+
+	api, err := basiq.NewAPI(config)
+	if err != nil {
+		t.Fatalf("NewAPI: %v", err)
+	}
+	return api, &pages
+}
@@ -8,58 +8,115 @@ import (
 	"net/url"
 )
 
-type PayRequestParams struct {
-	PayRequests []struct {
-		RequestID           string `json:"requestId"`
-		Description         string `json:"description"`
-		Amount              int    `json:"amount"`
-		CollectFundsToFloat bool   `json:"collectFundsToFloat,omitempty"`
-		CheckAccountBalance bool   `json:"checkAccountBalance,omitempty"`
-		Payer               struct {
-			PayerUserID         string `json:"payerUserId"`
-			PayerBankBranchCode string `json:"payerBankBranchCode,omitempty"`
-			PayerAccountNumber  string `json:"payerAccountNumber,omitempty"`
-		} `json:"payer"`
+// PaymentStatus represents the state of a pay request or a payout.
+type PaymentStatus string
+
+const (
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusProcessing PaymentStatus = "processing"
+	PaymentStatusSuccess    PaymentStatus = "success"
+	PaymentStatusCompleted  PaymentStatus = "completed"
+	PaymentStatusFailed     PaymentStatus = "failed"
+	PaymentStatusCancelled  PaymentStatus = "cancelled"
+)
+
+// IsTerminal returns true when the payment can't change its status anymore.
+func (s PaymentStatus) IsTerminal() bool {
+	switch s {
+	case PaymentStatusSuccess, PaymentStatusCompleted, PaymentStatusFailed, PaymentStatusCancelled:
+		return true
+	default:
+		return false
 	}
 }
 
+// IsSuccessful returns true when the payment has been successfully processed.
+func (s PaymentStatus) IsSuccessful() bool {
+	return s == PaymentStatusSuccess || s == PaymentStatusCompleted
+}
+
+func (s *PaymentStatus) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*s = PaymentStatus(v)
+	return err
+}
+
+type PayRequestParams struct {
+	PayRequests []PayRequestItem `json:"payRequests"`
+}
+
+// PayRequestItem represents a single pay request of the batch sent to Basiq.
+type PayRequestItem struct {
+	RequestID           string `json:"requestId"`
+	Description         string `json:"description"`
+	Amount              int    `json:"amount"`
+	CollectFundsToFloat bool   `json:"collectFundsToFloat,omitempty"`
+	CheckAccountBalance bool   `json:"checkAccountBalance,omitempty"`
+	Payer               Payer  `json:"payer"`
+}
+
+// Payer represents the user the funds are collected from. PayerAccountID is set by Basiq only.
+type Payer struct {
+	PayerUserID         string `json:"payerUserId"`
+	PayerAccountID      string `json:"payerAccountId,omitempty"`
+	PayerBankBranchCode string `json:"payerBankBranchCode,omitempty"`
+	PayerAccountNumber  string `json:"payerAccountNumber,omitempty"`
+}
+
 type PayRequestList struct {
 	Type  string       `json:"type"`
 	Count int          `json:"count"`
 	Size  int          `json:"size"`
 	Data  []PayRequest `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-		Next string `json:"next"`
-	} `json:"links"`
+	Links PageLinks    `json:"links"`
 }
 
 type PayRequest struct {
-	Type      string `json:"type"`
-	ID        string `json:"id"`
-	RequestID string `json:"requestId"`
-	Created   string `json:"created"`
-	Updated   string `json:"updated"`
-	Method    string `json:"method"`
-	Status    string `json:"status"`
-	Reason    struct {
+	Type        string        `json:"type"`
+	ID          string        `json:"id"`
+	RequestID   string        `json:"requestId"`
+	Created     Timestamp     `json:"created"`
+	Updated     Timestamp     `json:"updated"`
+	Method      string        `json:"method"`
+	Status      PaymentStatus `json:"status"`
+	Reason      PaymentReason `json:"reason"`
+	Payer       Payer         `json:"payer"`
+	Description string        `json:"description"`
+	Amount      int           `json:"amount"`
+	Currency    string        `json:"currency"`
+	Links       JobLinks      `json:"links"`
+}
+
+// PaymentReason describes why the payment has failed.
+type PaymentReason struct {
+	Code    string `json:"code"`
+	Title   string `json:"title"`
+	Details string `json:"details"`
+
+	// Detail holds the same value as Details, it's kept for the payout reasons that used to expose it.
+	//
+	// Deprecated: use Details.
+	Detail string `json:"detail,omitempty"`
+}
+
+// UnmarshalJSON accepts both "details" used by pay requests and "detail" used by payouts.
+func (r *PaymentReason) UnmarshalJSON(data []byte) error {
+	var v struct {
 		Code    string `json:"code"`
 		Title   string `json:"title"`
 		Details string `json:"details"`
-	} `json:"reason"`
-	Payer struct {
-		PayerUserID         string `json:"payerUserId"`
-		PayerAccountID      string `json:"payerAccountId"`
-		PayerBankBranchCode string `json:"payerBankBranchCode"`
-		PayerAccountNumber  string `json:"payerAccountNumber"`
-	} `json:"payer"`
-	Description string `json:"description"`
-	Amount      int    `json:"amount"`
-	Currency    string `json:"currency"`
-	Links       struct {
-		Self string `json:"self"`
-		Job  string `json:"job"`
-	} `json:"links"`
+		Detail  string `json:"detail"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*r = PaymentReason{Code: v.Code, Title: v.Title, Details: v.Details}
+	if r.Details == "" {
+		r.Details = v.Detail
+	}
+	r.Detail = r.Details
+	return nil
 }
 
 type PayRequestJobList struct {
@@ -67,19 +124,17 @@ type PayRequestJobList struct {
 }
 
 type PayRequestJob struct {
-	Type      string `json:"type"`
-	ID        string `json:"id"`
-	RequestID string `json:"requestId"`
-	Links     struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Type      string   `json:"type"`
+	ID        string   `json:"id"`
+	RequestID string   `json:"requestId"`
+	Links     SelfLink `json:"links"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) PayRequest(ctx context.Context, payRequestID string) (PayRequest, error) {
 	payRequest, err := a.payRequest(ctx, payRequestID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return payRequest, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -90,7 +145,7 @@ func (a *API) PayRequest(ctx context.Context, payRequestID string) (PayRequest,
 
 func (a *API) PayRequests(ctx context.Context) ([]PayRequest, error) {
 	payRequests, err := a.payRequests(ctx)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return payRequests, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -101,7 +156,7 @@ func (a *API) PayRequests(ctx context.Context) ([]PayRequest, error) {
 
 func (a *API) CreatePayRequest(ctx context.Context, params PayRequestParams) ([]PayRequestJob, error) {
 	payRequestJobs, err := a.createPayRequest(ctx, params)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return payRequestJobs, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -113,7 +168,7 @@ func (a *API) CreatePayRequest(ctx context.Context, params PayRequestParams) ([]
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) payRequest(ctx context.Context, payRequestID string) (PayRequest, error) {
-	callURL, err := url.JoinPath(baseURL, "payments", "payrequests", payRequestID)
+	callURL, err := url.JoinPath(a.baseURL, "payments", "payrequests", payRequestID)
 	if err != nil {
 		return PayRequest{}, err
 	}
@@ -128,32 +183,16 @@ func (a *API) payRequest(ctx context.Context, payRequestID string) (PayRequest,
 }
 
 func (a *API) payRequests(ctx context.Context) ([]PayRequest, error) {
-	callURL, err := url.JoinPath(baseURL, "payments", "payrequests")
+	callURL, err := url.JoinPath(a.baseURL, "payments", "payrequests")
 	if err != nil {
 		return nil, err
 	}
 
-	var requests []PayRequest
-	for callURL != "" {
-		data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		var list PayRequestList
-		if err = json.Unmarshal(data, &list); err != nil {
-			return nil, err
-		}
-		if len(list.Data) > 0 {
-			requests = append(requests, list.Data...)
-		}
-		callURL = list.Links.Next
-	}
-
-	return requests, err
+	return listPages[PayRequest](ctx, a, callURL)
 }
 
 func (a *API) createPayRequest(ctx context.Context, params PayRequestParams) ([]PayRequestJob, error) {
-	callURL, err := url.JoinPath(baseURL, "payments", "payrequests")
+	callURL, err := url.JoinPath(a.baseURL, "payments", "payrequests")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,269 @@
+package basiq
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultPaymentConcurrency = 4
+
+// PaymentKind tells whether the payment is a pay request or a payout.
+type PaymentKind string
+
+const (
+	PaymentKindPayRequest PaymentKind = "payrequest"
+	PaymentKindPayout     PaymentKind = "payout"
+)
+
+// CanTransitionTo returns true when the payment can move from the status to the next one. Terminal statuses can't
+// change and processing payments can't become pending again. Unknown statuses are allowed to change into any
+// status, as their meaning is not known to the client.
+func (s PaymentStatus) CanTransitionTo(next PaymentStatus) bool {
+	switch {
+	case s == next || s == "":
+		return true
+	case s.IsTerminal():
+		return false
+	case s == PaymentStatusProcessing:
+		return next != PaymentStatusPending
+	default:
+		return true
+	}
+}
+
+// Payment is the common view of the tracked pay request or payout, only the field of its kind is set.
+type Payment struct {
+	Kind       PaymentKind
+	ID         string
+	JobID      string
+	Status     PaymentStatus
+	Reason     PaymentReason
+	PayRequest PayRequest
+	Payout     Payout
+}
+
+// PaymentWaitOptions configures how WaitForPayment polls the payment. Zero values fall back to the defaults.
+type PaymentWaitOptions struct {
+	// Interval is the initial pause between two polls, it's reset to this value whenever the status changes.
+	Interval time.Duration
+	// MaxInterval caps the backoff of the pause between two polls.
+	MaxInterval time.Duration
+	// OnStatus is called for every status transition, including the first status seen.
+	OnStatus func(PaymentStatusEvent)
+	// Concurrency limits how many payments WaitForPayments tracks at once, defaults to 4.
+	Concurrency int
+}
+
+// PaymentStatusEvent represents a change of the status of the payment.
+type PaymentStatusEvent struct {
+	PaymentID string
+	Kind      PaymentKind
+	Status    PaymentStatus
+	Previous  PaymentStatus
+}
+
+// PaymentResult is the outcome of a single payment tracked by WaitForPayments.
+type PaymentResult struct {
+	JobID   string
+	Payment Payment
+	Err     error
+}
+
+// PaymentError is returned when the payment has failed or has been cancelled, Reason holds the details from Basiq.
+type PaymentError struct {
+	PaymentID string
+	Kind      PaymentKind
+	Status    PaymentStatus
+	Reason    PaymentReason
+}
+
+func (e *PaymentError) Error() string {
+	return fmt.Sprintf("%s %s %s: %s: %s", e.Kind, e.PaymentID, e.Status, e.Reason.Title, e.Reason.Details)
+}
+
+// PaymentTransitionError is returned when the payment has changed its status in a way that is not allowed,
+// e.g. a completed payment has become pending again.
+type PaymentTransitionError struct {
+	PaymentID string
+	From      PaymentStatus
+	To        PaymentStatus
+}
+
+func (e *PaymentTransitionError) Error() string {
+	return fmt.Sprintf("payment %s changed its status from %s to %s", e.PaymentID, e.From, e.To)
+}
+
+// PayRequestJobIDs returns the IDs of the jobs returned by CreatePayRequest, so they can be tracked by
+// WaitForPayments.
+func PayRequestJobIDs(jobs []PayRequestJob) []string {
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+// PayoutJobIDs returns the IDs of the jobs returned by CreatePayout, so they can be tracked by WaitForPayments.
+func PayoutJobIDs(jobs []PayoutJob) []string {
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.ID)
+	}
+	return ids
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// WaitForPayment polls the payment job until it links the pay request or the payout and then polls the payment
+// until its status is terminal or the context is done. When the job fails the returned error is a *JobError,
+// failed and cancelled payments return a *PaymentError and illegal status changes a *PaymentTransitionError.
+// A job finished without linking any payment fails with an error as well.
+func (a *API) WaitForPayment(ctx context.Context, jobID string, opts PaymentWaitOptions) (Payment, error) {
+	opts = opts.withDefaults()
+
+	payment := Payment{JobID: jobID}
+	interval := opts.Interval
+	for {
+		progressed, done, err := a.pollPayment(ctx, &payment, opts)
+		if err != nil || done {
+			return payment, err
+		}
+
+		if progressed {
+			interval = opts.Interval
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return payment, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval = interval * 3 / 2
+		if interval > opts.MaxInterval {
+			interval = opts.MaxInterval
+		}
+	}
+}
+
+// WaitForPayments tracks all payment jobs with WaitForPayment, at most opts.Concurrency at once. The results are in
+// the order of the job IDs, failures are reported per result. Jobs not started before the context is done fail
+// with the context error.
+func (a *API) WaitForPayments(ctx context.Context, jobIDs []string, opts PaymentWaitOptions) []PaymentResult {
+	opts = opts.withDefaults()
+
+	results := make([]PaymentResult, len(jobIDs))
+	semaphore := make(chan struct{}, opts.Concurrency)
+	var wg sync.WaitGroup
+
+	for i := range jobIDs {
+		results[i].JobID = jobIDs[i]
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Payment.JobID = jobIDs[i]
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(result *PaymentResult) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			result.Payment, result.Err = a.WaitForPayment(ctx, result.JobID, opts)
+		}(&results[i])
+	}
+
+	wg.Wait()
+	return results
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// pollPayment resolves the payment from its job first and then refreshes its status. It returns whether the status
+// has changed and whether the tracking is done.
+func (a *API) pollPayment(ctx context.Context, payment *Payment, opts PaymentWaitOptions) (bool, bool, error) {
+	if payment.ID == "" {
+		job, err := a.Job(ctx, payment.JobID)
+		if err != nil {
+			return false, false, err
+		}
+		if err = job.Err(); err != nil {
+			return false, true, err
+		}
+
+		if id, ok := job.linkedID("payrequests"); ok {
+			payment.ID, payment.Kind = id, PaymentKindPayRequest
+		} else if id, ok = job.linkedID("payouts"); ok {
+			payment.ID, payment.Kind = id, PaymentKindPayout
+		} else if job.IsSuccessful() {
+			// the finished job won't link the payment anymore
+			return false, true, fmt.Errorf("basiq job %s doesn't link any payment", payment.JobID)
+		} else {
+			return false, false, nil
+		}
+	}
+
+	next, err := a.payment(ctx, payment.Kind, payment.ID)
+	if err != nil {
+		return false, false, err
+	}
+	next.JobID = payment.JobID
+
+	previous := payment.Status
+	if !previous.CanTransitionTo(next.Status) {
+		return false, true, &PaymentTransitionError{PaymentID: payment.ID, From: previous, To: next.Status}
+	}
+	*payment = next
+
+	progressed := previous != next.Status
+	if progressed && opts.OnStatus != nil {
+		opts.OnStatus(PaymentStatusEvent{PaymentID: next.ID, Kind: next.Kind, Status: next.Status, Previous: previous})
+	}
+
+	if !next.Status.IsTerminal() {
+		return progressed, false, nil
+	}
+	if !next.Status.IsSuccessful() {
+		return progressed, true, &PaymentError{PaymentID: next.ID, Kind: next.Kind, Status: next.Status, Reason: next.Reason}
+	}
+	return progressed, true, nil
+}
+
+func (a *API) payment(ctx context.Context, kind PaymentKind, paymentID string) (Payment, error) {
+	if kind == PaymentKindPayout {
+		payout, err := a.Payout(ctx, paymentID)
+		if err != nil {
+			return Payment{}, err
+		}
+		return Payment{Kind: kind, ID: payout.ID, Status: payout.Status, Reason: payout.Reason, Payout: payout}, nil
+	}
+
+	payRequest, err := a.PayRequest(ctx, paymentID)
+	if err != nil {
+		return Payment{}, err
+	}
+	return Payment{Kind: kind, ID: payRequest.ID, Status: payRequest.Status, Reason: payRequest.Reason, PayRequest: payRequest}, nil
+}
+
+func (o PaymentWaitOptions) withDefaults() PaymentWaitOptions {
+	if o.Interval <= 0 {
+		o.Interval = defaultJobInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = defaultJobMaxInterval
+	}
+	if o.MaxInterval < o.Interval {
+		o.MaxInterval = o.Interval
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = defaultPaymentConcurrency
+	}
+	return o
+}
@@ -0,0 +1,53 @@
+package basiq_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+func TestWaitForPaymentCompletes(t *testing.T) {
+	api, srv, _ := newTestAPI(t)
+
+	jobs, err := api.CreatePayout(context.Background(), basiq.PayoutParams{
+		RequestID:   "refund",
+		Description: "Refund",
+		Amount:      2500,
+		Payee:       basiq.Payee{PayeeUserID: "user-1"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePayout: %v", err)
+	}
+	payouts, err := api.Payouts(context.Background())
+	if err != nil || len(payouts) != 1 {
+		t.Fatalf("Payouts returned %d payouts: %v", len(payouts), err)
+	}
+	srv.SetPaymentStatus(payouts[0].ID, basiq.PaymentStatusCompleted, basiq.PaymentReason{})
+
+	payment, err := api.WaitForPayment(context.Background(), jobs[0].ID, basiq.PaymentWaitOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitForPayment: %v", err)
+	}
+	if payment.Kind != basiq.PaymentKindPayout || payment.ID != payouts[0].ID || payment.Status != basiq.PaymentStatusCompleted {
+		t.Errorf("unexpected payment %+v", payment)
+	}
+}
+
+func TestWaitForPaymentOfJobWithoutPayment(t *testing.T) {
+	api, srv, _ := newTestAPI(t)
+
+	job := srv.SetJob(basiq.Job{Steps: []basiq.JobStep{
+		{Title: basiq.JobStepVerifyCredentials, Status: basiq.JobStepStatusSuccess},
+	}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := api.WaitForPayment(ctx, job.ID, basiq.PaymentWaitOptions{Interval: time.Millisecond})
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitForPayment returned %v, want the missing payment error", err)
+	}
+}
@@ -13,50 +13,39 @@ type PayoutParams struct {
 	Method      string `json:"method,omitempty"`
 	Description string `json:"description"`
 	Amount      int    `json:"amount"`
-	Payee       struct {
-		PayeeUserID         string `json:"payeeUserId"`
-		PayeeBankBranchCode string `json:"payeeBankBranchCode"`
-		PayeeAccountNumber  string `json:"payeeAccountNumber"`
-	} `json:"payee"`
+	Payee       Payee  `json:"payee"`
+}
+
+// Payee represents the user the funds are paid out to. PayeeAccountID is set by Basiq only.
+type Payee struct {
+	PayeeUserID         string `json:"payeeUserId"`
+	PayeeAccountID      string `json:"payeeAccountId,omitempty"`
+	PayeeBankBranchCode string `json:"payeeBankBranchCode"`
+	PayeeAccountNumber  string `json:"payeeAccountNumber"`
 }
 
 type PayoutList struct {
-	Type  string   `json:"type"`
-	Count int      `json:"count"`
-	Size  int      `json:"size"`
-	Data  []Payout `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-		Next string `json:"next"`
-	} `json:"links"`
+	Type  string    `json:"type"`
+	Count int       `json:"count"`
+	Size  int       `json:"size"`
+	Data  []Payout  `json:"data"`
+	Links PageLinks `json:"links"`
 }
 
 type Payout struct {
-	Type      string `json:"type"`
-	ID        string `json:"id"`
-	RequestID string `json:"requestId"`
-	Created   string `json:"created"`
-	Updated   string `json:"updated"`
-	Method    string `json:"method"`
-	Status    string `json:"status"`
-	Reason    struct {
-		Code   string `json:"code"`
-		Title  string `json:"title"`
-		Detail string `json:"detail"`
-	} `json:"reason"`
-	Payee struct {
-		PayeeUserID         string `json:"payeeUserId"`
-		PayeeAccountID      string `json:"payeeAccountId"`
-		PayeeBankBranchCode string `json:"payeeBankBranchCode"`
-		PayeeAccountNumber  string `json:"payeeAccountNumber"`
-	} `json:"payee"`
-	Description string `json:"description"`
-	Amount      string `json:"amount"`
-	Currency    string `json:"currency"`
-	Links       struct {
-		Self string `json:"self"`
-		Job  string `json:"job"`
-	} `json:"links"`
+	Type        string        `json:"type"`
+	ID          string        `json:"id"`
+	RequestID   string        `json:"requestId"`
+	Created     Timestamp     `json:"created"`
+	Updated     Timestamp     `json:"updated"`
+	Method      string        `json:"method"`
+	Status      PaymentStatus `json:"status"`
+	Reason      PaymentReason `json:"reason"`
+	Payee       Payee         `json:"payee"`
+	Description string        `json:"description"`
+	Amount      string        `json:"amount"`
+	Currency    string        `json:"currency"`
+	Links       JobLinks      `json:"links"`
 }
 
 type PayoutJobList struct {
@@ -64,19 +53,17 @@ type PayoutJobList struct {
 }
 
 type PayoutJob struct {
-	Type      string `json:"type"`
-	ID        string `json:"id"`
-	RequestID string `json:"requestId"`
-	Links     struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Type      string   `json:"type"`
+	ID        string   `json:"id"`
+	RequestID string   `json:"requestId"`
+	Links     SelfLink `json:"links"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) Payout(ctx context.Context, payoutID string) (Payout, error) {
 	payout, err := a.payout(ctx, payoutID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return payout, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -87,7 +74,7 @@ func (a *API) Payout(ctx context.Context, payoutID string) (Payout, error) {
 
 func (a *API) Payouts(ctx context.Context) ([]Payout, error) {
 	payouts, err := a.payouts(ctx)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return payouts, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -98,7 +85,7 @@ func (a *API) Payouts(ctx context.Context) ([]Payout, error) {
 
 func (a *API) CreatePayout(ctx context.Context, params PayoutParams) ([]PayoutJob, error) {
 	payoutJobs, err := a.createPayout(ctx, params)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return payoutJobs, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -110,7 +97,7 @@ func (a *API) CreatePayout(ctx context.Context, params PayoutParams) ([]PayoutJo
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) payout(ctx context.Context, payoutID string) (Payout, error) {
-	callURL, err := url.JoinPath(baseURL, "payments", "payouts", payoutID)
+	callURL, err := url.JoinPath(a.baseURL, "payments", "payouts", payoutID)
 	if err != nil {
 		return Payout{}, err
 	}
@@ -125,32 +112,16 @@ func (a *API) payout(ctx context.Context, payoutID string) (Payout, error) {
 }
 
 func (a *API) payouts(ctx context.Context) ([]Payout, error) {
-	callURL, err := url.JoinPath(baseURL, "payments", "payouts")
+	callURL, err := url.JoinPath(a.baseURL, "payments", "payouts")
 	if err != nil {
 		return nil, err
 	}
 
-	var payouts []Payout
-	for callURL != "" {
-		data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		var list PayoutList
-		if err = json.Unmarshal(data, &list); err != nil {
-			return nil, err
-		}
-		if len(list.Data) > 0 {
-			payouts = append(payouts, list.Data...)
-		}
-		callURL = list.Links.Next
-	}
-
-	return payouts, err
+	return listPages[Payout](ctx, a, callURL)
 }
 
 func (a *API) createPayout(ctx context.Context, params PayoutParams) ([]PayoutJob, error) {
-	callURL, err := url.JoinPath(baseURL, "payments", "payouts")
+	callURL, err := url.JoinPath(a.baseURL, "payments", "payouts")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,33 @@
+package basiq_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+func TestPaymentReasonUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"pay request reason", `{"code":"insufficient-funds","title":"Insufficient funds","details":"Balance too low"}`},
+		{"payout reason", `{"code":"insufficient-funds","title":"Insufficient funds","detail":"Balance too low"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payout basiq.Payout
+			if err := json.Unmarshal([]byte(`{"reason":`+tt.input+`}`), &payout); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if payout.Reason.Details != "Balance too low" {
+				t.Errorf("Details = %q, want %q", payout.Reason.Details, "Balance too low")
+			}
+			if payout.Reason.Detail != payout.Reason.Details {
+				t.Errorf("Detail = %q, want %q", payout.Reason.Detail, payout.Reason.Details)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package basiq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ReportTemplate represents the kind of the report generated by Basiq.
+type ReportTemplate string
+
+const (
+	ReportTemplateAffordability       ReportTemplate = "CON_AFFOR_01"
+	ReportTemplateIncomeVerification  ReportTemplate = "CON_INCV_01"
+	ReportTemplateExpenseVerification ReportTemplate = "CON_EXPV_01"
+)
+
+func (t *ReportTemplate) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*t = ReportTemplate(v)
+	return err
+}
+
+// Names of the report filters.
+const (
+	ReportFilterUsers    = "users"
+	ReportFilterAccounts = "accounts"
+	ReportFilterFromDate = "fromDate"
+	ReportFilterToDate   = "toDate"
+)
+
+// ReportParams describes the report to generate. The report covers the users and optionally only some of their
+// accounts and the date range, the filters are built from the fields.
+type ReportParams struct {
+	Template   ReportTemplate
+	Title      string
+	UserIDs    []string
+	AccountIDs []string
+	FromDate   Date
+	ToDate     Date
+}
+
+// Validate checks the template and the users are set and the date range is valid.
+func (p ReportParams) Validate() error {
+	switch {
+	case p.Template == "":
+		return errors.New("basiq report template is required")
+	case len(p.UserIDs) == 0:
+		return errors.New("basiq report requires at least one user")
+	case !p.FromDate.IsZero() && !p.ToDate.IsZero() && p.FromDate.After(p.ToDate.Time):
+		return fmt.Errorf("fromDate %s is after toDate %s", p.FromDate, p.ToDate)
+	default:
+		return nil
+	}
+}
+
+// Filters returns the filters of the report, unset fields are omitted.
+func (p ReportParams) Filters() []ReportFilter {
+	filters := []ReportFilter{{Name: ReportFilterUsers, Value: p.UserIDs}}
+	if len(p.AccountIDs) > 0 {
+		filters = append(filters, ReportFilter{Name: ReportFilterAccounts, Value: p.AccountIDs})
+	}
+	if !p.FromDate.IsZero() {
+		filters = append(filters, ReportFilter{Name: ReportFilterFromDate, Value: []string{p.FromDate.String()}})
+	}
+	if !p.ToDate.IsZero() {
+		filters = append(filters, ReportFilter{Name: ReportFilterToDate, Value: []string{p.ToDate.String()}})
+	}
+	return filters
+}
+
+func (p ReportParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		ReportType ReportTemplate `json:"reportType"`
+		Title      string         `json:"title,omitempty"`
+		Filters    []ReportFilter `json:"filters"`
+	}{
+		ReportType: p.Template,
+		Title:      p.Title,
+		Filters:    p.Filters(),
+	})
+}
+
+// ReportFilter is a single filter of the report. The date filters hold a single value, which is sent as a string.
+type ReportFilter struct {
+	Name  string
+	Value []string
+}
+
+func (f ReportFilter) MarshalJSON() ([]byte, error) {
+	var value interface{} = f.Value
+	if (f.Name == ReportFilterFromDate || f.Name == ReportFilterToDate) && len(f.Value) == 1 {
+		value = f.Value[0]
+	}
+	return json.Marshal(struct {
+		Name  string      `json:"name"`
+		Value interface{} `json:"value"`
+	}{
+		Name:  f.Name,
+		Value: value,
+	})
+}
+
+func (f *ReportFilter) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Name  string          `json:"name"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*f = ReportFilter{Name: v.Name}
+	if len(v.Value) == 0 {
+		return nil
+	}
+	return unmarshalOneOrMany(v.Value, &f.Value)
+}
+
+type ReportList struct {
+	Type  string    `json:"type"`
+	Data  []Report  `json:"data"`
+	Links PageLinks `json:"links"`
+}
+
+// Report is the generated verification report. The content of Data depends on the template, decode it with
+// DecodeData into the matching structure.
+type Report struct {
+	Type        string          `json:"type"`
+	ID          string          `json:"id"`
+	ReportType  ReportTemplate  `json:"reportType"`
+	Title       string          `json:"title"`
+	CreatedDate Timestamp       `json:"createdDate"`
+	Filters     []ReportFilter  `json:"filters"`
+	Data        json.RawMessage `json:"data"`
+	Links       SelfLink        `json:"links"`
+}
+
+// DecodeData decodes the content of the report into v.
+func (r Report) DecodeData(v interface{}) error {
+	if len(r.Data) == 0 {
+		return errors.New("basiq report has no data")
+	}
+	return json.Unmarshal(r.Data, v)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// CreateReport starts generating the report and returns its job, the report is generated asynchronously. Use
+// WaitForReport to get the report once it's ready. In the consent guard mode the consents of all users of the
+// report have to cover their accounts and transactions.
+func (a *API) CreateReport(ctx context.Context, params ReportParams) (Job, error) {
+	if err := params.Validate(); err != nil {
+		return Job{}, err
+	}
+	for _, userID := range params.UserIDs {
+		if err := a.guard(ctx, userID, ConsentEntityAccounts, ConsentEntityTransactions); err != nil {
+			return Job{}, err
+		}
+	}
+
+	job, err := a.createReport(ctx, params)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return job, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return Job{}, err
+	}
+	return a.createReport(ctx, params)
+}
+
+func (a *API) Report(ctx context.Context, reportID string) (Report, error) {
+	report, err := a.report(ctx, reportID)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return report, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return Report{}, err
+	}
+	return a.report(ctx, reportID)
+}
+
+func (a *API) Reports(ctx context.Context) ([]Report, error) {
+	reports, err := a.reports(ctx)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return reports, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return a.reports(ctx)
+}
+
+// WaitForReport polls the report job the same way as WaitForJob and returns the generated report. When the job
+// fails the returned error is a *JobError.
+func (a *API) WaitForReport(ctx context.Context, jobID string, opts JobWaitOptions) (Report, error) {
+	job, err := a.WaitForJob(ctx, jobID, opts)
+	if err != nil {
+		return Report{}, err
+	}
+
+	reportID, ok := job.linkedID("reports")
+	if !ok {
+		return Report{}, fmt.Errorf("basiq job %s doesn't link any report", jobID)
+	}
+	return a.Report(ctx, reportID)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (a *API) createReport(ctx context.Context, params ReportParams) (Job, error) {
+	callURL, err := url.JoinPath(a.baseURL, "reports")
+	if err != nil {
+		return Job{}, err
+	}
+
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return Job{}, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodPost, callURL, bytes.NewReader(payload))
+	if err != nil {
+		return Job{}, err
+	}
+
+	var job Job
+	return job, json.Unmarshal(data, &job)
+}
+
+func (a *API) report(ctx context.Context, reportID string) (Report, error) {
+	callURL, err := url.JoinPath(a.baseURL, "reports", reportID)
+	if err != nil {
+		return Report{}, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
+	if err != nil {
+		return Report{}, err
+	}
+
+	var report Report
+	return report, json.Unmarshal(data, &report)
+}
+
+func (a *API) reports(ctx context.Context) ([]Report, error) {
+	callURL, err := url.JoinPath(a.baseURL, "reports")
+	if err != nil {
+		return nil, err
+	}
+
+	return listPages[Report](ctx, a, callURL)
+}
@@ -0,0 +1,119 @@
+package basiq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+)
+
+const defaultStatementFileName = "statement.pdf"
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// StatementParams holds the bank statement uploaded to create a connection of the user, the statement is read
+// whole into memory before the upload.
+type StatementParams struct {
+	InstitutionID string
+	// FileName of the statement, defaults to statement.pdf.
+	FileName  string
+	Statement io.Reader
+}
+
+// Validate checks the institution and the statement are set.
+func (p StatementParams) Validate() error {
+	switch {
+	case p.InstitutionID == "":
+		return errors.New("basiq statement institution ID is required")
+	case p.Statement == nil:
+		return errors.New("basiq statement file is required")
+	default:
+		return nil
+	}
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+// UploadStatement creates a connection of the user from the bank statement and returns the job processing it,
+// follow it with WaitForJob like other connection jobs.
+func (a *API) UploadStatement(ctx context.Context, userID string, params StatementParams) (Job, error) {
+	if err := params.Validate(); err != nil {
+		return Job{}, err
+	}
+
+	payload, contentType, err := params.multipart()
+	if err != nil {
+		return Job{}, err
+	}
+
+	job, err := a.uploadStatement(ctx, userID, contentType, payload)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return job, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return Job{}, err
+	}
+	return a.uploadStatement(ctx, userID, contentType, payload)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (a *API) uploadStatement(ctx context.Context, userID, contentType string, payload []byte) (Job, error) {
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "statements")
+	if err != nil {
+		return Job{}, err
+	}
+
+	data, err := a.makeCallWithContentType(ctx, http.MethodPost, callURL, contentType, bytes.NewReader(payload))
+	if err != nil {
+		return Job{}, err
+	}
+
+	var job Job
+	return job, json.Unmarshal(data, &job)
+}
+
+// multipart encodes the params into the multipart/form-data body, so it can be sent again after authentication.
+func (p StatementParams) multipart() ([]byte, string, error) {
+	statement, err := io.ReadAll(p.Statement)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(statement) == 0 {
+		return nil, "", errors.New("basiq statement file is empty")
+	}
+
+	fileName := p.FileName
+	if fileName == "" {
+		fileName = defaultStatementFileName
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err = writer.WriteField("institutionId", p.InstitutionID); err != nil {
+		return nil, "", err
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="statement"; filename="%s"`, quoteEscaper.Replace(fileName)))
+	header.Set("Content-Type", http.DetectContentType(statement))
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err = part.Write(statement); err != nil {
+		return nil, "", err
+	}
+	if err = writer.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return body.Bytes(), writer.FormDataContentType(), nil
+}
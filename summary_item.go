@@ -0,0 +1,128 @@
+package basiq
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+type IncomeSummaryItemList struct {
+	Type  string              `json:"type"`
+	Data  []IncomeSummaryItem `json:"data"`
+	Links SelfLink            `json:"links"`
+}
+
+// IncomeSummaryItem is the entry of the income summaries list, use its ID with IncomeSummary to get the details
+// instead of generating the summary again.
+type IncomeSummaryItem struct {
+	Type          string        `json:"type"`
+	ID            string        `json:"id"`
+	CoverageDays  int           `json:"coverageDays"`
+	FromMonth     Month         `json:"fromMonth"`
+	ToMonth       Month         `json:"toMonth"`
+	GeneratedDate Timestamp     `json:"generatedDate"`
+	Links         SnapshotLinks `json:"links"`
+}
+
+type ExpenseSummaryItemList struct {
+	Type  string               `json:"type"`
+	Data  []ExpenseSummaryItem `json:"data"`
+	Links SelfLink             `json:"links"`
+}
+
+// ExpenseSummaryItem is the entry of the expense summaries list, use its ID with ExpenseSummary to get the details
+// instead of generating the summary again.
+type ExpenseSummaryItem struct {
+	Type          string        `json:"type"`
+	ID            string        `json:"id"`
+	CoverageDays  int           `json:"coverageDays"`
+	FromMonth     Month         `json:"fromMonth"`
+	ToMonth       Month         `json:"toMonth"`
+	GeneratedDate Timestamp     `json:"generatedDate"`
+	Links         SnapshotLinks `json:"links"`
+}
+
+const (
+	incomeResource   = "income"
+	expensesResource = "expenses"
+)
+
+//---------------------------------------------------------------------------------------------------------------------
+
+func (a *API) IncomeSummaries(ctx context.Context, userID string) ([]IncomeSummaryItem, error) {
+	return summaryItems[IncomeSummaryItem](ctx, a, userID, incomeResource)
+}
+
+// DeleteIncomeSummary deletes the income summary of the user, it can't be read afterwards.
+func (a *API) DeleteIncomeSummary(ctx context.Context, userID, snapshotID string) error {
+	return a.deleteSummary(ctx, userID, incomeResource, snapshotID)
+}
+
+func (a *API) ExpenseSummaries(ctx context.Context, userID string) ([]ExpenseSummaryItem, error) {
+	return summaryItems[ExpenseSummaryItem](ctx, a, userID, expensesResource)
+}
+
+// DeleteExpenseSummary deletes the expense summary of the user, it can't be read afterwards.
+func (a *API) DeleteExpenseSummary(ctx context.Context, userID, snapshotID string) error {
+	return a.deleteSummary(ctx, userID, expensesResource, snapshotID)
+}
+
+//---------------------------------------------------------------------------------------------------------------------
+
+// summaryItems lists the summaries the user has in the resource, either income or expenses.
+func summaryItems[T any](ctx context.Context, a *API, userID, resource string) ([]T, error) {
+	if err := a.guard(ctx, userID, ConsentEntityTransactions); err != nil {
+		return nil, err
+	}
+
+	items, err := listSummaryItems[T](ctx, a, userID, resource)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return items, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return listSummaryItems[T](ctx, a, userID, resource)
+}
+
+func (a *API) deleteSummary(ctx context.Context, userID, resource, snapshotID string) error {
+	err := a.deleteSummaryItem(ctx, userID, resource, snapshotID)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return err
+	}
+	return a.deleteSummaryItem(ctx, userID, resource, snapshotID)
+}
+
+func listSummaryItems[T any](ctx context.Context, a *API, userID, resource string) ([]T, error) {
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Data []T `json:"data"`
+	}
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return list.Data, nil
+}
+
+func (a *API) deleteSummaryItem(ctx context.Context, userID, resource, snapshotID string) error {
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, resource, snapshotID)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.makeCall(ctx, http.MethodDelete, callURL, nil)
+	return err
+}
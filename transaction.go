@@ -7,90 +7,96 @@ import (
 	"net/url"
 )
 
+// TransactionDirection represents whether the money went into or out of the account.
+type TransactionDirection string
+
+const (
+	TransactionDirectionDebit  TransactionDirection = "debit"
+	TransactionDirectionCredit TransactionDirection = "credit"
+)
+
+func (d *TransactionDirection) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*d = TransactionDirection(v)
+	return err
+}
+
+// TransactionClass represents the classification of the transaction done by Basiq.
+type TransactionClass string
+
+const (
+	TransactionClassBankFee        TransactionClass = "bank-fee"
+	TransactionClassPayment        TransactionClass = "payment"
+	TransactionClassCashWithdrawal TransactionClass = "cash-withdrawal"
+	TransactionClassTransfer       TransactionClass = "transfer"
+	TransactionClassLoanInterest   TransactionClass = "loan-interest"
+	TransactionClassRefund         TransactionClass = "refund"
+	TransactionClassDirectCredit   TransactionClass = "direct-credit"
+	TransactionClassInterest       TransactionClass = "interest"
+	TransactionClassLoanRepayment  TransactionClass = "loan-repayment"
+)
+
+func (c *TransactionClass) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*c = TransactionClass(v)
+	return err
+}
+
+// TransactionStatus represents whether the transaction has been already posted by the institution.
+type TransactionStatus string
+
+const (
+	TransactionStatusPosted  TransactionStatus = "posted"
+	TransactionStatusPending TransactionStatus = "pending"
+)
+
+func (s *TransactionStatus) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*s = TransactionStatus(v)
+	return err
+}
+
 type TransactionList struct {
 	Type  string        `json:"type"`
 	Count int           `json:"count"`
 	Size  int           `json:"size"`
 	Data  []Transaction `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-		Next string `json:"next"`
-	} `json:"links"`
+	Links PageLinks     `json:"links"`
 }
 
 type Transaction struct {
-	Type        string `json:"type"`
-	ID          string `json:"id"`
+	Type            string               `json:"type"`
+	ID              string               `json:"id"`
+	Account         string               `json:"account"`
+	Amount          string               `json:"amount"`
+	Balance         string               `json:"balance"`
+	Class           TransactionClass     `json:"class"`
+	Connection      string               `json:"connection"`
+	Description     string               `json:"description"`
+	Direction       TransactionDirection `json:"direction"`
+	Enrich          Enrichment           `json:"enrich"`
+	Institution     string               `json:"institution"`
+	PostDate        Date                 `json:"postDate"`
+	Status          TransactionStatus    `json:"status"`
+	TransactionDate Date                 `json:"transactionDate"`
+	Links           TransactionLinks     `json:"links"`
+}
+
+type TransactionLinks struct {
 	Account     string `json:"account"`
-	Amount      string `json:"amount"`
-	Balance     string `json:"balance"`
-	Class       string `json:"class"`
-	Connection  string `json:"connection"`
-	Description string `json:"description"`
-	Direction   string `json:"direction"`
-	Enrich      struct {
-		Category struct {
-			Anzsic struct {
-				Class struct {
-					Title string `json:"title"`
-					Code  string `json:"code"`
-				} `json:"class"`
-				Division struct {
-					Title string `json:"title"`
-					Code  string `json:"code"`
-				} `json:"division"`
-				Group struct {
-					Code  string `json:"code"`
-					Title string `json:"title"`
-				} `json:"group"`
-				Subdivision struct {
-					Code  string `json:"code"`
-					Title string `json:"title"`
-				} `json:"subdivision"`
-			} `json:"anzsic"`
-		} `json:"category"`
-		Location struct {
-			Country          string `json:"country"`
-			FormattedAddress string `json:"formattedAddress"`
-			Geometry         struct {
-				Lat string `json:"lat"`
-				Lng string `json:"lng"`
-			} `json:"geometry"`
-			PostalCode string `json:"postalCode"`
-			Route      string `json:"route"`
-			RouteNo    string `json:"routeNo"`
-			State      string `json:"state"`
-			Suburb     string `json:"suburb"`
-		} `json:"location"`
-		Merchant struct {
-			ID           string `json:"id"`
-			BusinessName string `json:"businessName"`
-			ABN          int64  `json:"ABN"`
-			LogoMaster   string `json:"logoMaster"`
-			LogoThumb    string `json:"logoThumb"`
-			PhoneNumber  struct {
-				International string `json:"international"`
-				Local         string `json:"local"`
-			} `json:"phoneNumber"`
-			Website string `json:"website"`
-		} `json:"merchant"`
-	} `json:"enrich"`
-	Institution     string `json:"institution"`
-	PostDate        string `json:"postDate"`
-	Status          string `json:"status"`
-	TransactionDate string `json:"transactionDate"`
-	Links           struct {
-		Account     string `json:"account"`
-		Institution string `json:"institution"`
-		Self        string `json:"self"`
-	} `json:"links"`
+	Institution string `json:"institution"`
+	Self        string `json:"self"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) Transaction(ctx context.Context, userID, transactionID string) (Transaction, error) {
+	if err := a.guard(ctx, userID, ConsentEntityTransactions); err != nil {
+		return Transaction{}, err
+	}
+
 	transaction, err := a.transaction(ctx, userID, transactionID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return transaction, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -100,8 +106,12 @@ func (a *API) Transaction(ctx context.Context, userID, transactionID string) (Tr
 }
 
 func (a *API) Transactions(ctx context.Context, userID string) ([]Transaction, error) {
+	if err := a.guard(ctx, userID, ConsentEntityTransactions); err != nil {
+		return nil, err
+	}
+
 	transactions, err := a.transactions(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return transactions, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -113,7 +123,7 @@ func (a *API) Transactions(ctx context.Context, userID string) ([]Transaction, e
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) transaction(ctx context.Context, userID, transactionID string) (Transaction, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "transactions", transactionID)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "transactions", transactionID)
 	if err != nil {
 		return Transaction{}, err
 	}
@@ -128,26 +138,10 @@ func (a *API) transaction(ctx context.Context, userID, transactionID string) (Tr
 }
 
 func (a *API) transactions(ctx context.Context, userID string) ([]Transaction, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "transactions")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "transactions")
 	if err != nil {
 		return nil, err
 	}
 
-	var transactions []Transaction
-	for callURL != "" {
-		data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
-		if err != nil {
-			return nil, err
-		}
-		var list TransactionList
-		if err = json.Unmarshal(data, &list); err != nil {
-			return nil, err
-		}
-		if len(list.Data) > 0 {
-			transactions = append(transactions, list.Data...)
-		}
-		callURL = list.Links.Next
-	}
-
-	return transactions, err
+	return listPages[Transaction](ctx, a, callURL)
 }
@@ -4,46 +4,30 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 type User struct {
-	Type      string `json:"type"`
-	ID        string `json:"id"`
-	Email     string `json:"email"`
-	Mobile    string `json:"mobile"`
-	FirstName string `json:"firstName"`
-	LastName  string `json:"lastName"`
-	Name      string `json:"name"`
-	Accounts  struct {
-		Type  string `json:"type"`
-		Count int    `json:"count"`
-		Data  []struct {
-			Type  string `json:"type"`
-			ID    string `json:"id"`
-			Links struct {
-				Self string `json:"self"`
-			} `json:"links"`
-		} `json:"data"`
-	} `json:"accounts"`
-	Connections struct {
-		Type  string `json:"type"`
-		Count int    `json:"count"`
-		Data  []struct {
-			Type  string `json:"type"`
-			ID    string `json:"id"`
-			Links struct {
-				Self string `json:"self"`
-			} `json:"links"`
-		} `json:"data"`
-	} `json:"connections"`
-	Links struct {
-		Accounts     string `json:"accounts"`
-		Connections  string `json:"connections"`
-		Self         string `json:"self"`
-		Transactions string `json:"transactions"`
-	} `json:"links"`
+	Type        string          `json:"type"`
+	ID          string          `json:"id"`
+	Email       string          `json:"email"`
+	Mobile      string          `json:"mobile"`
+	FirstName   string          `json:"firstName"`
+	LastName    string          `json:"lastName"`
+	Name        string          `json:"name"`
+	Accounts    ResourceRefList `json:"accounts"`
+	Connections ResourceRefList `json:"connections"`
+	Links       UserLinks       `json:"links"`
+}
+
+type UserLinks struct {
+	Accounts     string `json:"accounts"`
+	Connections  string `json:"connections"`
+	Self         string `json:"self"`
+	Transactions string `json:"transactions"`
 }
 
 type UserParams struct {
@@ -53,11 +37,91 @@ type UserParams struct {
 	LastName  string `json:"lastName,omitempty"`
 }
 
+func (p UserParams) filter() (string, error) {
+	var filters []string
+	for _, f := range []struct{ field, value string }{{"email", p.Email}, {"mobile", p.Mobile}} {
+		if f.value == "" {
+			continue
+		}
+		expression, err := filterExpression(f.field, "eq", f.value)
+		if err != nil {
+			return "", err
+		}
+		filters = append(filters, expression)
+	}
+	return strings.Join(filters, ","), nil
+}
+
+type UserList struct {
+	Type  string    `json:"type"`
+	Count int       `json:"count"`
+	Size  int       `json:"size"`
+	Data  []User    `json:"data"`
+	Links PageLinks `json:"links"`
+}
+
+// UserIterator walks through the users page by page, the next page is loaded once the current one is consumed.
+//
+//	it := api.Users(ctx)
+//	for it.Next() {
+//		user := it.User()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type UserIterator struct {
+	api    *API
+	ctx    context.Context
+	cursor pageCursor
+	page   []User
+	user   User
+	err    error
+}
+
+// NewUserIterator returns the iterator over the given users without any API calls, it's useful to fake Users
+// in tests, e.g. in MockClient.UsersFunc.
+func NewUserIterator(users []User) *UserIterator {
+	return &UserIterator{page: append([]User(nil), users...)}
+}
+
+// Next moves to the next user, it returns false when there are no more users or loading of the page has failed.
+func (it *UserIterator) Next() bool {
+	if it == nil {
+		return false
+	}
+
+	for len(it.page) == 0 {
+		if it.err != nil || it.cursor.callURL == "" {
+			return false
+		}
+		it.loadPage()
+	}
+
+	it.user, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// User returns the current user.
+func (it *UserIterator) User() User {
+	if it == nil {
+		return User{}
+	}
+	return it.user
+}
+
+// Err returns the error that stopped the iteration.
+func (it *UserIterator) Err() error {
+	if it == nil {
+		return nil
+	}
+	return it.err
+}
+
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) User(ctx context.Context, userID string) (User, error) {
 	user, err := a.user(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return user, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -68,7 +132,7 @@ func (a *API) User(ctx context.Context, userID string) (User, error) {
 
 func (a *API) CreateUser(ctx context.Context, params UserParams) (User, error) {
 	user, err := a.createUser(ctx, params)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return user, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -79,7 +143,7 @@ func (a *API) CreateUser(ctx context.Context, params UserParams) (User, error) {
 
 func (a *API) UpdateUser(ctx context.Context, userID string, params UserParams) (User, error) {
 	user, err := a.updateUser(ctx, userID, params)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return user, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -90,7 +154,7 @@ func (a *API) UpdateUser(ctx context.Context, userID string, params UserParams)
 
 func (a *API) DeleteUser(ctx context.Context, userID string) error {
 	err := a.deleteUser(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -99,10 +163,33 @@ func (a *API) DeleteUser(ctx context.Context, userID string) error {
 	return a.deleteUser(ctx, userID)
 }
 
+// Users returns the iterator over all users of the application.
+func (a *API) Users(ctx context.Context) *UserIterator {
+	return a.userIterator(ctx, "")
+}
+
+// FindUsers returns the users matching the email and the mobile of the params, the other fields are not used.
+func (a *API) FindUsers(ctx context.Context, params UserParams) ([]User, error) {
+	filter, err := params.filter()
+	if err != nil {
+		return nil, err
+	}
+	if filter == "" {
+		return nil, errors.New("basiq email or mobile is required to find users")
+	}
+
+	var users []User
+	it := a.userIterator(ctx, filter)
+	for it.Next() {
+		users = append(users, it.User())
+	}
+	return users, it.Err()
+}
+
 //---------------------------------------------------------------------------------------------------------------------
 
 func (a *API) user(ctx context.Context, userID string) (User, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID)
 	if err != nil {
 		return User{}, err
 	}
@@ -117,7 +204,7 @@ func (a *API) user(ctx context.Context, userID string) (User, error) {
 }
 
 func (a *API) createUser(ctx context.Context, params UserParams) (User, error) {
-	callURL, err := url.JoinPath(baseURL, "users")
+	callURL, err := url.JoinPath(a.baseURL, "users")
 	if err != nil {
 		return User{}, err
 	}
@@ -137,7 +224,7 @@ func (a *API) createUser(ctx context.Context, params UserParams) (User, error) {
 }
 
 func (a *API) updateUser(ctx context.Context, userID string, params UserParams) (User, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID)
 	if err != nil {
 		return User{}, err
 	}
@@ -157,7 +244,7 @@ func (a *API) updateUser(ctx context.Context, userID string, params UserParams)
 }
 
 func (a *API) deleteUser(ctx context.Context, userID string) error {
-	callURL, err := url.JoinPath(baseURL, "users", userID)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID)
 	if err != nil {
 		return err
 	}
@@ -165,3 +252,37 @@ func (a *API) deleteUser(ctx context.Context, userID string) error {
 	_, err = a.makeCall(ctx, http.MethodDelete, callURL, nil)
 	return err
 }
+
+func (a *API) userIterator(ctx context.Context, filter string) *UserIterator {
+	callURL, err := url.JoinPath(a.baseURL, "users")
+	if filter != "" {
+		callURL += "?" + url.Values{"filter": {filter}}.Encode()
+	}
+	return &UserIterator{api: a, ctx: ctx, cursor: pageCursor{callURL: callURL}, err: err}
+}
+
+func (a *API) userPage(ctx context.Context, callURL string) (UserList, error) {
+	data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
+	if err != nil {
+		return UserList{}, err
+	}
+
+	var list UserList
+	return list, json.Unmarshal(data, &list)
+}
+
+func (it *UserIterator) loadPage() {
+	list, err := it.api.userPage(it.ctx, it.cursor.callURL)
+	if err != nil && IsUnauthorizedErr(err) {
+		if err = it.api.Authenticate(it.ctx); err == nil {
+			list, err = it.api.userPage(it.ctx, it.cursor.callURL)
+		}
+	}
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = list.Data
+	it.err = it.cursor.advance(list.Links.Next)
+}
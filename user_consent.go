@@ -3,58 +3,187 @@ package basiq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
+	"sort"
+	"time"
 )
 
+var (
+	ErrNoActiveConsent = errors.New("basiq user has no active consent")
+)
+
+// ConsentStatus represents the state of the consent.
+type ConsentStatus string
+
+const (
+	ConsentStatusActive  ConsentStatus = "active"
+	ConsentStatusRevoked ConsentStatus = "revoked"
+	ConsentStatusExpired ConsentStatus = "expired"
+)
+
+func (s *ConsentStatus) UnmarshalJSON(data []byte) error {
+	v, err := decodeEnum(data)
+	*s = ConsentStatus(v)
+	return err
+}
+
+type UserConsentList struct {
+	Type  string        `json:"type"`
+	Size  int           `json:"size"`
+	Data  []UserConsent `json:"data"`
+	Links SelfLink      `json:"links"`
+}
+
 type UserConsent struct {
-	Type       string `json:"type"`
-	ID         string `json:"id"`
-	Created    string `json:"created"`
-	Updated    string `json:"updated"`
-	ExpiryDate string `json:"expiryDate"`
-	Status     string `json:"status"`
-	Purpose    struct {
-		Primary struct {
-			Title       string `json:"title"`
-			Description string `json:"description"`
-		} `json:"primary"`
-	} `json:"purpose"`
-	Data struct {
-		RetainData  bool `json:"retainData"`
-		Permissions []struct {
-			Scope       string `json:"scope"`
-			Required    bool   `json:"required"`
-			Entity      string `json:"entity"`
-			Information struct {
-				Name          string   `json:"name"`
-				Description   string   `json:"description"`
-				AttributeList []string `json:"attributeList"`
-			} `json:"information"`
-			Purpose struct {
-				Title       string `json:"title"`
-				Description string `json:"description"`
-			} `json:"purpose"`
-		} `json:"permissions"`
-	} `json:"data"`
+	Type       string          `json:"type"`
+	ID         string          `json:"id"`
+	Created    Timestamp       `json:"created"`
+	Updated    Timestamp       `json:"updated"`
+	ExpiryDate Timestamp       `json:"expiryDate"`
+	Status     ConsentStatus   `json:"status"`
+	Purpose    ConsentPurposes `json:"purpose"`
+	Data       ConsentData     `json:"data"`
+}
+
+// IsActive returns true when the consent is active and hasn't expired at the given time.
+func (c UserConsent) IsActive(at time.Time) bool {
+	return c.Status == ConsentStatusActive && (c.ExpiryDate.IsZero() || c.ExpiryDate.After(at))
+}
+
+// ExpiresWithin returns true when the active consent expires within the window from the given time.
+func (c UserConsent) ExpiresWithin(window time.Duration, at time.Time) bool {
+	return c.IsActive(at) && !c.ExpiryDate.IsZero() && c.ExpiryDate.Before(at.Add(window))
+}
+
+// ActiveConsent returns the active consent expiring last from the consents.
+func ActiveConsent(consents []UserConsent) (UserConsent, bool) {
+	now := time.Now()
+
+	var active UserConsent
+	var found bool
+	for _, consent := range consents {
+		if !consent.IsActive(now) {
+			continue
+		}
+		if !found || consent.expiresAfter(active) {
+			active, found = consent, true
+		}
+	}
+	return active, found
+}
+
+// findConsent returns the consent with the ID in any status, the most recently updated consent is returned
+// when the ID is empty.
+func findConsent(consents []UserConsent, consentID string) (UserConsent, bool) {
+	var found UserConsent
+	var ok bool
+	for _, consent := range consents {
+		switch {
+		case consentID != "" && consent.ID == consentID:
+			return consent, true
+		case consentID == "" && (!ok || consent.Updated.After(found.Updated.Time)):
+			found, ok = consent, true
+		}
+	}
+	return found, ok
+}
+
+// ExpiringConsent is an active consent of the user expiring soon.
+type ExpiringConsent struct {
+	UserID    string
+	Consent   UserConsent
+	ExpiresIn time.Duration
+}
+
+type ConsentPurposes struct {
+	Primary Purpose `json:"primary"`
+}
+
+type Purpose struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type ConsentData struct {
+	RetainData  bool                `json:"retainData"`
+	Permissions []ConsentPermission `json:"permissions"`
+}
+
+// ConsentPermission represents the access to a single entity (e.g. accounts, transactions) the user agreed to.
+type ConsentPermission struct {
+	Scope       string             `json:"scope"`
+	Required    bool               `json:"required"`
+	Entity      string             `json:"entity"`
+	Information ConsentInformation `json:"information"`
+	Purpose     Purpose            `json:"purpose"`
+}
+
+type ConsentInformation struct {
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	AttributeList []string `json:"attributeList"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
-func (a *API) UserConsent(ctx context.Context, userID string) (UserConsent, error) {
-	userConsent, err := a.userConsent(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
-		return userConsent, err
+func (a *API) UserConsents(ctx context.Context, userID string) ([]UserConsent, error) {
+	consents, err := a.userConsents(ctx, userID)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return consents, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return a.userConsents(ctx, userID)
+}
+
+// UserConsent returns the active consent of the user, ErrNoActiveConsent is returned when there is none.
+func (a *API) UserConsent(ctx context.Context, userID string) (UserConsent, error) {
+	consents, err := a.UserConsents(ctx, userID)
+	if err != nil {
 		return UserConsent{}, err
 	}
-	return a.userConsent(ctx, userID)
+
+	consent, ok := ActiveConsent(consents)
+	if !ok {
+		return UserConsent{}, ErrNoActiveConsent
+	}
+	return consent, nil
+}
+
+// ExpiringConsents scans the consents of the users and returns the active consents expiring within the window,
+// sorted from the one expiring first. Users without any active consent are skipped.
+func (a *API) ExpiringConsents(ctx context.Context, userIDs []string, window time.Duration) ([]ExpiringConsent, error) {
+	now := time.Now()
+
+	var expiring []ExpiringConsent
+	for _, userID := range userIDs {
+		consents, err := a.UserConsents(ctx, userID)
+		if err != nil {
+			return expiring, err
+		}
+		for _, consent := range consents {
+			if consent.ExpiresWithin(window, now) {
+				expiring = append(expiring, ExpiringConsent{
+					UserID:    userID,
+					Consent:   consent,
+					ExpiresIn: consent.ExpiryDate.Sub(now),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].ExpiresIn < expiring[j].ExpiresIn
+	})
+	return expiring, nil
 }
 
 func (a *API) DeleteUserConsent(ctx context.Context, userID, consentID string) error {
 	err := a.deleteUserConsent(ctx, userID, consentID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -65,23 +194,26 @@ func (a *API) DeleteUserConsent(ctx context.Context, userID, consentID string) e
 
 // --------------------------------------------------------------------------------------------------------------------
 
-func (a *API) userConsent(ctx context.Context, userID string) (UserConsent, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "consents")
+func (a *API) userConsents(ctx context.Context, userID string) ([]UserConsent, error) {
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "consents")
 	if err != nil {
-		return UserConsent{}, err
+		return nil, err
 	}
 
 	data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
 	if err != nil {
-		return UserConsent{}, err
+		return nil, err
 	}
 
-	var consent UserConsent
-	return consent, json.Unmarshal(data, &consent)
+	var list UserConsentList
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return list.Data, nil
 }
 
 func (a *API) deleteUserConsent(ctx context.Context, userID, consentID string) error {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "consents", consentID)
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "consents", consentID)
 	if err != nil {
 		return err
 	}
@@ -89,3 +221,15 @@ func (a *API) deleteUserConsent(ctx context.Context, userID, consentID string) e
 	_, err = a.makeCall(ctx, http.MethodDelete, callURL, nil)
 	return err
 }
+
+// expiresAfter compares the expiry dates, zero expiry date means the consent doesn't expire.
+func (c UserConsent) expiresAfter(other UserConsent) bool {
+	switch {
+	case other.ExpiryDate.IsZero():
+		return false
+	case c.ExpiryDate.IsZero():
+		return true
+	default:
+		return c.ExpiryDate.After(other.ExpiryDate.Time)
+	}
+}
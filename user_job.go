@@ -11,46 +11,24 @@ type UserJobList struct {
 	Type  string    `json:"type"`
 	Size  int       `json:"size"`
 	Data  []UserJob `json:"data"`
-	Links struct {
-		Self string `json:"self"`
-	} `json:"links"`
+	Links SelfLink  `json:"links"`
 }
 
 type UserJob struct {
-	Type        string `json:"type"`
-	ID          string `json:"id"`
-	Created     string `json:"created"`
-	Updated     string `json:"updated"`
-	Institution struct {
-		Type  string `json:"type"`
-		ID    string `json:"id"`
-		Links struct {
-			Self   string `json:"self"`
-			Source string `json:"source"`
-		} `json:"links"`
-	} `json:"institution"`
-	Steps []struct {
-		Title  string `json:"title"`
-		Status string `json:"status"`
-		Result struct {
-			Code    string `json:"code"`
-			Details string `json:"details"`
-			Title   string `json:"title"`
-			Type    string `json:"type"`
-			URL     string `json:"url"`
-		} `json:"result"`
-	} `json:"steps"`
-	Links struct {
-		Self   string `json:"self"`
-		Source string `json:"source"`
-	} `json:"links"`
+	Type        string         `json:"type"`
+	ID          string         `json:"id"`
+	Created     Timestamp      `json:"created"`
+	Updated     Timestamp      `json:"updated"`
+	Institution InstitutionRef `json:"institution"`
+	Steps       []JobStep      `json:"steps"`
+	Links       SourceLinks    `json:"links"`
 }
 
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) UserJobs(ctx context.Context, userID string) ([]UserJob, error) {
 	userJobs, err := a.userJobs(ctx, userID)
-	if err != nil && !IsUnauthorizedErr(err) {
+	if err == nil || !IsUnauthorizedErr(err) {
 		return userJobs, err
 	}
 	if err = a.Authenticate(ctx); err != nil {
@@ -62,7 +40,7 @@ func (a *API) UserJobs(ctx context.Context, userID string) ([]UserJob, error) {
 // --------------------------------------------------------------------------------------------------------------------
 
 func (a *API) userJobs(ctx context.Context, userID string) ([]UserJob, error) {
-	callURL, err := url.JoinPath(baseURL, "users", userID, "jobs")
+	callURL, err := url.JoinPath(a.baseURL, "users", userID, "jobs")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,42 @@
+package basiq_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+func TestMockClientUsers(t *testing.T) {
+	mock := &basiq.MockClient{
+		UsersFunc: func(context.Context) *basiq.UserIterator {
+			return basiq.NewUserIterator([]basiq.User{{ID: "user-1"}, {ID: "user-2"}})
+		},
+	}
+
+	var ids []string
+	it := mock.Users(context.Background())
+	for it.Next() {
+		ids = append(ids, it.User().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Users: %v", err)
+	}
+	if strings.Join(ids, ",") != "user-1,user-2" {
+		t.Errorf("Users returned %v, want [user-1 user-2]", ids)
+	}
+}
+
+func TestFindUsersRejectsQuotedValues(t *testing.T) {
+	api, _, counter := newTestAPI(t)
+
+	_, err := api.FindUsers(context.Background(), basiq.UserParams{Email: "jane@example.com'),mobile.eq('+61400000000"})
+	if err == nil {
+		t.Fatal("FindUsers accepted the quoted email")
+	}
+	if n := counter.count(http.MethodGet, "/users"); n != 0 {
+		t.Errorf("GET /users sent %d times, want none", n)
+	}
+}
@@ -0,0 +1,238 @@
+package basiq
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+type WebhookParams struct {
+	URL              string   `json:"url,omitempty"`
+	Description      string   `json:"description,omitempty"`
+	SubscribedEvents []string `json:"subscribedEvents,omitempty"`
+}
+
+type WebhookList struct {
+	Type  string    `json:"type"`
+	Data  []Webhook `json:"data"`
+	Links SelfLink  `json:"links"`
+}
+
+type Webhook struct {
+	Type             string    `json:"type"`
+	ID               string    `json:"id"`
+	URL              string    `json:"url"`
+	Description      string    `json:"description"`
+	Status           string    `json:"status"`
+	SubscribedEvents []string  `json:"subscribedEvents"`
+	CreatedDate      Timestamp `json:"createdDate"`
+	UpdatedDate      Timestamp `json:"updatedDate"`
+	Links            SelfLink  `json:"links"`
+}
+
+// WebhookSecret holds the signing secret of the webhook, use it in the WebhookConfig of the receiver.
+type WebhookSecret struct {
+	Type   string `json:"type"`
+	Secret string `json:"secret"`
+}
+
+type WebhookTestParams struct {
+	EventTypeID string `json:"eventTypeId"`
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (a *API) Webhook(ctx context.Context, webhookID string) (Webhook, error) {
+	webhook, err := a.webhook(ctx, webhookID)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return webhook, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return Webhook{}, err
+	}
+	return a.webhook(ctx, webhookID)
+}
+
+func (a *API) Webhooks(ctx context.Context) ([]Webhook, error) {
+	webhooks, err := a.webhooks(ctx)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return webhooks, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return a.webhooks(ctx)
+}
+
+func (a *API) CreateWebhook(ctx context.Context, params WebhookParams) (Webhook, error) {
+	webhook, err := a.createWebhook(ctx, params)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return webhook, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return Webhook{}, err
+	}
+	return a.createWebhook(ctx, params)
+}
+
+func (a *API) UpdateWebhook(ctx context.Context, webhookID string, params WebhookParams) (Webhook, error) {
+	webhook, err := a.updateWebhook(ctx, webhookID, params)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return webhook, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return Webhook{}, err
+	}
+	return a.updateWebhook(ctx, webhookID, params)
+}
+
+func (a *API) DeleteWebhook(ctx context.Context, webhookID string) error {
+	err := a.deleteWebhook(ctx, webhookID)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return err
+	}
+	return a.deleteWebhook(ctx, webhookID)
+}
+
+func (a *API) WebhookSecret(ctx context.Context, webhookID string) (WebhookSecret, error) {
+	secret, err := a.webhookSecret(ctx, webhookID)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return secret, err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return WebhookSecret{}, err
+	}
+	return a.webhookSecret(ctx, webhookID)
+}
+
+// SendTestWebhook asks Basiq to deliver a test message of the given event type to the webhook.
+func (a *API) SendTestWebhook(ctx context.Context, webhookID string, params WebhookTestParams) error {
+	err := a.sendTestWebhook(ctx, webhookID, params)
+	if err == nil || !IsUnauthorizedErr(err) {
+		return err
+	}
+	if err = a.Authenticate(ctx); err != nil {
+		return err
+	}
+	return a.sendTestWebhook(ctx, webhookID, params)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (a *API) webhook(ctx context.Context, webhookID string) (Webhook, error) {
+	callURL, err := url.JoinPath(a.baseURL, "notifications", "webhooks", webhookID)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	var webhook Webhook
+	return webhook, json.Unmarshal(data, &webhook)
+}
+
+func (a *API) webhooks(ctx context.Context) ([]Webhook, error) {
+	callURL, err := url.JoinPath(a.baseURL, "notifications", "webhooks")
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var list WebhookList
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	return list.Data, nil
+}
+
+func (a *API) createWebhook(ctx context.Context, params WebhookParams) (Webhook, error) {
+	callURL, err := url.JoinPath(a.baseURL, "notifications", "webhooks")
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodPost, callURL, bytes.NewReader(payload))
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	var webhook Webhook
+	return webhook, json.Unmarshal(data, &webhook)
+}
+
+func (a *API) updateWebhook(ctx context.Context, webhookID string, params WebhookParams) (Webhook, error) {
+	callURL, err := url.JoinPath(a.baseURL, "notifications", "webhooks", webhookID)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodPost, callURL, bytes.NewReader(payload))
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	var webhook Webhook
+	return webhook, json.Unmarshal(data, &webhook)
+}
+
+func (a *API) deleteWebhook(ctx context.Context, webhookID string) error {
+	callURL, err := url.JoinPath(a.baseURL, "notifications", "webhooks", webhookID)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.makeCall(ctx, http.MethodDelete, callURL, nil)
+	return err
+}
+
+func (a *API) webhookSecret(ctx context.Context, webhookID string) (WebhookSecret, error) {
+	callURL, err := url.JoinPath(a.baseURL, "notifications", "webhooks", webhookID, "secret")
+	if err != nil {
+		return WebhookSecret{}, err
+	}
+
+	data, err := a.makeCall(ctx, http.MethodGet, callURL, nil)
+	if err != nil {
+		return WebhookSecret{}, err
+	}
+
+	var secret WebhookSecret
+	return secret, json.Unmarshal(data, &secret)
+}
+
+func (a *API) sendTestWebhook(ctx context.Context, webhookID string, params WebhookTestParams) error {
+	callURL, err := url.JoinPath(a.baseURL, "notifications", "webhooks", webhookID, "test")
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.makeCall(ctx, http.MethodPost, callURL, bytes.NewReader(payload))
+	return err
+}
@@ -0,0 +1,294 @@
+package basiq
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	webhookIDHeader        = "webhook-id"
+	webhookTimestampHeader = "webhook-timestamp"
+	webhookSignatureHeader = "webhook-signature"
+	webhookSecretPrefix    = "whsec_"
+	webhookSignaturePrefix = "v1,"
+	webhookMaxBodyBytes    = 1 << 20
+)
+
+var defaultWebhookTolerance = 5 * time.Minute
+
+var (
+	ErrWebhookSignature = errors.New("basiq webhook signature is invalid")
+	ErrWebhookTimestamp = errors.New("basiq webhook timestamp is outside of the tolerance")
+	ErrWebhookReplay    = errors.New("basiq webhook message has been already received")
+)
+
+// Event types Basiq sends to the webhooks.
+const (
+	EventUserCreated           = "user.created"
+	EventUserUpdated           = "user.updated"
+	EventUserDeleted           = "user.deleted"
+	EventConnectionCreated     = "connection.created"
+	EventConnectionDeleted     = "connection.deleted"
+	EventConnectionInvalidated = "connection.invalidated"
+	EventAccountUpdated        = "account.updated"
+	EventTransactionsUpdated   = "transactions.updated"
+	EventConsentCreated        = "consent.created"
+	EventConsentUpdated        = "consent.updated"
+	EventConsentRevoked        = "consent.revoked"
+	EventPayRequestUpdated     = "payrequest.updated"
+	EventPayoutUpdated         = "payout.updated"
+)
+
+// WebhookConfig represents the set of input parameters of the webhook receiver.
+type WebhookConfig struct {
+	// Secret is the signing secret of the webhook in the "whsec_..." form.
+	Secret string
+	// Tolerance is the maximal age of the message, defaults to 5 minutes.
+	Tolerance time.Duration
+}
+
+// Validate checks all necessary input parameters and returns error when some of them are not set.
+func (c WebhookConfig) Validate() error {
+	switch {
+	case c.Secret == "":
+		return errors.New("basiq webhook secret is required")
+	case c.Tolerance < 0:
+		return errors.New("basiq webhook tolerance can't be negative")
+	default:
+		return nil
+	}
+}
+
+// WebhookEvent is the payload Basiq sends to the webhook. It builds on the Event, the links of the webhook message
+// point to the event resource.
+type WebhookEvent struct {
+	Event
+	EventID     string       `json:"eventId"`
+	EventTypeID string       `json:"eventTypeId"`
+	Links       WebhookLinks `json:"links"`
+}
+
+func (e *WebhookEvent) UnmarshalJSON(data []byte) error {
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return err
+	}
+
+	var v struct {
+		EventID     string       `json:"eventId"`
+		EventTypeID string       `json:"eventTypeId"`
+		Links       WebhookLinks `json:"links"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*e = WebhookEvent{Event: event, EventID: v.EventID, EventTypeID: v.EventTypeID, Links: v.Links}
+	return nil
+}
+
+type WebhookLinks struct {
+	Event string `json:"event"`
+}
+
+// Name returns the type of the event (e.g. "connection.created").
+func (e WebhookEvent) Name() string {
+	switch {
+	case e.EventTypeID != "":
+		return e.EventTypeID
+	case e.Entity != "" && e.EventType != "":
+		return string(e.Entity) + "." + e.EventType
+	default:
+		return e.EventType
+	}
+}
+
+// WebhookHandlerFunc processes a single webhook event, returned error makes Basiq deliver the event again.
+type WebhookHandlerFunc func(ctx context.Context, event WebhookEvent) error
+
+// WebhookReceiver is the http.Handler receiving Basiq webhooks. It verifies the signature of every message, rejects
+// replayed messages and dispatches the events to the handlers registered per event type.
+// WebhookReceiver is thread safe struct.
+type WebhookReceiver struct {
+	secret    []byte
+	tolerance time.Duration
+	handlers  map[string]WebhookHandlerFunc
+	fallback  WebhookHandlerFunc
+	seen      map[string]time.Time
+	now       func() time.Time
+	m         sync.RWMutex
+}
+
+// NewWebhookReceiver instantiates the receiver and checks all input parameters.
+func NewWebhookReceiver(config WebhookConfig) (*WebhookReceiver, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(config.Secret, webhookSecretPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("basiq webhook secret is invalid: %w", err)
+	}
+
+	tolerance := config.Tolerance
+	if tolerance == 0 {
+		tolerance = defaultWebhookTolerance
+	}
+
+	return &WebhookReceiver{
+		secret:    secret,
+		tolerance: tolerance,
+		handlers:  map[string]WebhookHandlerFunc{},
+		seen:      map[string]time.Time{},
+		now:       time.Now,
+	}, nil
+}
+
+// Handle registers the handler of the event type (e.g. EventConnectionCreated).
+func (r *WebhookReceiver) Handle(eventType string, handler WebhookHandlerFunc) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.handlers[eventType] = handler
+}
+
+// HandleDefault registers the handler of all event types without their own handler.
+func (r *WebhookReceiver) HandleDefault(handler WebhookHandlerFunc) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.fallback = handler
+}
+
+// Verify checks the signature and the timestamp of the message. Every message passes the verification only once,
+// so messages without the ID are rejected as invalid.
+func (r *WebhookReceiver) Verify(header http.Header, body []byte) error {
+	id := header.Get(webhookIDHeader)
+	if id == "" {
+		return ErrWebhookSignature
+	}
+	timestamp := header.Get(webhookTimestampHeader)
+
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrWebhookTimestamp
+	}
+	sentAt := time.Unix(sec, 0)
+	now := r.now()
+	if now.Sub(sentAt).Abs() > r.tolerance {
+		return ErrWebhookTimestamp
+	}
+
+	if !r.validSignature(id, timestamp, body, header.Get(webhookSignatureHeader)) {
+		return ErrWebhookSignature
+	}
+
+	return r.remember(id, sentAt, now)
+}
+
+func (r *WebhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, webhookMaxBodyBytes))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = r.Verify(req.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var event WebhookEvent
+	if err = json.Unmarshal(body, &event); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err = r.dispatch(req.Context(), event); err != nil {
+		// the message has to be accepted again when Basiq retries the delivery
+		r.forget(req.Header.Get(webhookIDHeader))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --------------------------------------------------------------------------------------------------------------------
+
+func (r *WebhookReceiver) validSignature(id, timestamp string, body []byte, signatures string) bool {
+	mac := hmac.New(sha256.New, r.secret)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	for _, signature := range strings.Fields(signatures) {
+		if !strings.HasPrefix(signature, webhookSignaturePrefix) {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(signature, webhookSignaturePrefix))
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return true
+		}
+	}
+	return false
+}
+
+// remember stores the message ID for the tolerance window, messages older than that are rejected by the timestamp.
+func (r *WebhookReceiver) remember(id string, sentAt, now time.Time) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	for seenID, seenAt := range r.seen {
+		if now.Sub(seenAt) > r.tolerance {
+			delete(r.seen, seenID)
+		}
+	}
+
+	if _, ok := r.seen[id]; ok {
+		return ErrWebhookReplay
+	}
+	r.seen[id] = sentAt
+	return nil
+}
+
+func (r *WebhookReceiver) forget(id string) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	delete(r.seen, id)
+}
+
+func (r *WebhookReceiver) dispatch(ctx context.Context, event WebhookEvent) error {
+	r.m.RLock()
+	handler, ok := r.handlers[event.Name()]
+	if !ok {
+		handler = r.fallback
+	}
+	r.m.RUnlock()
+
+	if handler == nil {
+		return nil
+	}
+	return handler(ctx, event)
+}
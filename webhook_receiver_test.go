@@ -0,0 +1,123 @@
+package basiq_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lukasaron/basiq-go"
+)
+
+const webhookBody = `{"type":"event","id":"event-1","eventId":"event-1","eventTypeId":"user.created"}`
+
+var (
+	webhookSecret      = []byte("webhook-test-secret")
+	webhookOtherSecret = []byte("another-test-secret")
+)
+
+type webhookDelivery struct {
+	id         string
+	age        time.Duration
+	secret     []byte
+	wantStatus int
+}
+
+func TestWebhookReceiver(t *testing.T) {
+	tests := []struct {
+		name       string
+		failures   int
+		deliveries []webhookDelivery
+		wantCalls  int
+	}{
+		{
+			name:       "valid signature",
+			deliveries: []webhookDelivery{{id: "msg-1", secret: webhookSecret, wantStatus: http.StatusNoContent}},
+			wantCalls:  1,
+		},
+		{
+			name:       "bad signature",
+			deliveries: []webhookDelivery{{id: "msg-1", secret: webhookOtherSecret, wantStatus: http.StatusUnauthorized}},
+		},
+		{
+			name:       "missing message ID",
+			deliveries: []webhookDelivery{{secret: webhookSecret, wantStatus: http.StatusUnauthorized}},
+		},
+		{
+			name:       "stale timestamp",
+			deliveries: []webhookDelivery{{id: "msg-1", age: 10 * time.Minute, secret: webhookSecret, wantStatus: http.StatusUnauthorized}},
+		},
+		{
+			name: "replayed message",
+			deliveries: []webhookDelivery{
+				{id: "msg-1", secret: webhookSecret, wantStatus: http.StatusNoContent},
+				{id: "msg-1", secret: webhookSecret, wantStatus: http.StatusUnauthorized},
+			},
+			wantCalls: 1,
+		},
+		{
+			name:     "handler error accepts retry",
+			failures: 1,
+			deliveries: []webhookDelivery{
+				{id: "msg-1", secret: webhookSecret, wantStatus: http.StatusInternalServerError},
+				{id: "msg-1", secret: webhookSecret, wantStatus: http.StatusNoContent},
+			},
+			wantCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver, err := basiq.NewWebhookReceiver(basiq.WebhookConfig{
+				Secret: "whsec_" + base64.StdEncoding.EncodeToString(webhookSecret),
+			})
+			if err != nil {
+				t.Fatalf("NewWebhookReceiver: %v", err)
+			}
+
+			var calls int
+			receiver.Handle(basiq.EventUserCreated, func(_ context.Context, event basiq.WebhookEvent) error {
+				calls++
+				if event.EventID != "event-1" {
+					t.Errorf("unexpected event ID %q", event.EventID)
+				}
+				if calls <= tt.failures {
+					return errors.New("handler failed")
+				}
+				return nil
+			})
+
+			for i, delivery := range tt.deliveries {
+				res := httptest.NewRecorder()
+				receiver.ServeHTTP(res, newWebhookRequest(delivery))
+				if res.Code != delivery.wantStatus {
+					t.Errorf("delivery %d: status %d, want %d: %s", i, res.Code, delivery.wantStatus, res.Body)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("handler called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func newWebhookRequest(delivery webhookDelivery) *http.Request {
+	timestamp := strconv.FormatInt(time.Now().Add(-delivery.age).Unix(), 10)
+
+	mac := hmac.New(sha256.New, delivery.secret)
+	mac.Write([]byte(delivery.id + "." + timestamp + "." + webhookBody))
+	signature := "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/basiq", bytes.NewBufferString(webhookBody))
+	req.Header.Set("webhook-id", delivery.id)
+	req.Header.Set("webhook-timestamp", timestamp)
+	req.Header.Set("webhook-signature", signature)
+	return req
+}